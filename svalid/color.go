@@ -0,0 +1,39 @@
+package svalid
+
+import (
+	"errors"
+	"strings"
+
+	"fyne.io/fyne/v2"
+)
+
+// HexColor defines a validator for hexadecimal colors like "#1e88e5" or
+// "#fff". If allowAlpha is true, the alpha can be added at the end (like
+// "#1e88e580"). The "#" is optional and empty strings are accepted.
+func HexColor(allowAlpha bool) fyne.StringValidator {
+	return func(s string) error {
+		if s == "" {
+			return nil
+		}
+		digits := strings.TrimPrefix(s, "#")
+		switch len(digits) {
+		case 3, 6:
+		case 4, 8:
+			if !allowAlpha {
+				return errors.New(errMsgs.HexColor)
+			}
+		default:
+			return errors.New(errMsgs.HexColor)
+		}
+		for _, r := range digits {
+			if !isHexDigit(r) {
+				return errors.New(errMsgs.HexColor)
+			}
+		}
+		return nil
+	}
+}
+
+func isHexDigit(r rune) bool {
+	return r >= '0' && r <= '9' || r >= 'a' && r <= 'f' || r >= 'A' && r <= 'F'
+}
@@ -4,6 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"net/mail"
+	"strconv"
+	"strings"
+	"time"
 
 	"fyne.io/fyne/v2"
 )
@@ -38,3 +41,171 @@ func MinLength(min int) fyne.StringValidator {
 		return nil
 	}
 }
+
+// Number defines number validator. Empty strings are accepted, use
+// NotEmpty to require a value.
+func Number() fyne.StringValidator {
+	return func(s string) error {
+		if s == "" {
+			return nil
+		}
+		if _, err := strconv.ParseFloat(s, 64); err != nil {
+			return errors.New(errMsgs.Number)
+		}
+		return nil
+	}
+}
+
+// Min defines min value validator.
+func Min(min float64) fyne.StringValidator {
+	return func(s string) error {
+		v, err := parseNumber(s)
+		if err != nil || v == nil {
+			return err
+		}
+		if *v < min {
+			return fmt.Errorf(errMsgs.Min, min)
+		}
+		return nil
+	}
+}
+
+// Max defines max value validator.
+func Max(max float64) fyne.StringValidator {
+	return func(s string) error {
+		v, err := parseNumber(s)
+		if err != nil || v == nil {
+			return err
+		}
+		if *v > max {
+			return fmt.Errorf(errMsgs.Max, max)
+		}
+		return nil
+	}
+}
+
+// Range defines value range validator (both limits included).
+func Range(min, max float64) fyne.StringValidator {
+	return func(s string) error {
+		v, err := parseNumber(s)
+		if err != nil || v == nil {
+			return err
+		}
+		if *v < min || *v > max {
+			return fmt.Errorf(errMsgs.Range, min, max)
+		}
+		return nil
+	}
+}
+
+// parseNumber returns nil if the string is empty.
+func parseNumber(s string) (*float64, error) {
+	if s == "" {
+		return nil, nil
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return nil, errors.New(errMsgs.Number)
+	}
+	return &v, nil
+}
+
+// Time defines date/time validator for the layout (see time.Parse).
+// Empty strings are accepted, use NotEmpty to require a value.
+func Time(layout string) fyne.StringValidator {
+	return TimeRange(layout, time.Time{}, time.Time{})
+}
+
+// TimeRange defines date/time range validator for the layout. A zero min
+// or max is not checked.
+func TimeRange(layout string, min, max time.Time) fyne.StringValidator {
+	return func(s string) error {
+		if s == "" {
+			return nil
+		}
+		t, err := time.ParseInLocation(layout, s, time.Local)
+		if err != nil {
+			return errors.New(errMsgs.Time)
+		}
+		if !min.IsZero() && t.Before(min) {
+			return fmt.Errorf(errMsgs.MinTime, min.Format(layout))
+		}
+		if !max.IsZero() && t.After(max) {
+			return fmt.Errorf(errMsgs.MaxTime, max.Format(layout))
+		}
+		return nil
+	}
+}
+
+// Checked defines a validator for check and switch fields that must be
+// checked (like the acceptance of terms).
+func Checked() func(bool) error {
+	return func(checked bool) error {
+		if !checked {
+			return errors.New(errMsgs.Checked)
+		}
+		return nil
+	}
+}
+
+// MinSelected defines a validator for multiple selection fields that
+// requires at least min selected options.
+func MinSelected(min int) func([]string) error {
+	return func(selected []string) error {
+		if len(selected) < min {
+			return fmt.Errorf(errMsgs.MinSelected, min)
+		}
+		return nil
+	}
+}
+
+// MaxSelected defines a validator for multiple selection fields that
+// allows at most max selected options.
+func MaxSelected(max int) func([]string) error {
+	return func(selected []string) error {
+		if len(selected) > max {
+			return fmt.Errorf(errMsgs.MaxSelected, max)
+		}
+		return nil
+	}
+}
+
+// MaxTags defines a validator for tags fields that allows at most max
+// tags.
+func MaxTags(max int) func([]string) error {
+	return func(tags []string) error {
+		if len(tags) > max {
+			return fmt.Errorf(errMsgs.MaxTags, max)
+		}
+		return nil
+	}
+}
+
+// NotDuplicated defines a validator that rejects the values already
+// present in values, ignoring the case.
+func NotDuplicated(values []string) fyne.StringValidator {
+	return func(s string) error {
+		for _, v := range values {
+			if strings.EqualFold(v, s) {
+				return errors.New(errMsgs.Duplicated)
+			}
+		}
+		return nil
+	}
+}
+
+// OneOf defines a validator that only accepts one of the options. Empty
+// strings are accepted, use NotEmpty to require a value.
+func OneOf(options []string) fyne.StringValidator {
+	return func(s string) error {
+		if s == "" {
+			return nil
+		}
+		for _, o := range options {
+			if o == s {
+				return nil
+			}
+		}
+		return errors.New(errMsgs.OneOf)
+	}
+}
@@ -1,16 +1,48 @@
 package svalid
 
 var errMsgs = ErrorMessages{
-	NotEmpty:  "This field cannot be empty",
-	Email:     "",
-	MinLength: "Min length must be %d",
+	NotEmpty:    "This field cannot be empty",
+	Email:       "",
+	MinLength:   "Min length must be %d",
+	Number:      "This field must be a number",
+	Min:         "Min value must be %v",
+	Max:         "Max value must be %v",
+	Range:       "Value must be between %v and %v",
+	Time:        "Invalid date or time",
+	MinTime:     "Must not be before %s",
+	MaxTime:     "Must not be after %s",
+	Checked:     "This option must be checked",
+	MinSelected: "Select at least %d options",
+	MaxSelected: "Select at most %d options",
+	OneOf:       "Select one of the options",
+	FileType:    "File type not allowed",
+	FileSize:    "File must not be larger than %s",
+	HexColor:    "Invalid color",
+	MaxTags:     "Add at most %d tags",
+	Duplicated:  "This value was already added",
 }
 
 // ErrorMessages defines all the error messages.
 type ErrorMessages struct {
-	NotEmpty  string
-	Email     string
-	MinLength string
+	NotEmpty    string
+	Email       string
+	MinLength   string
+	Number      string
+	Min         string
+	Max         string
+	Range       string
+	Time        string
+	MinTime     string
+	MaxTime     string
+	Checked     string
+	MinSelected string
+	MaxSelected string
+	OneOf       string
+	FileType    string
+	FileSize    string
+	HexColor    string
+	MaxTags     string
+	Duplicated  string
 }
 
 // ConfigErrMessages configure the error messages for validation.
@@ -24,4 +56,52 @@ func ConfigErrMessages(msgs *ErrorMessages) {
 	if msgs.MinLength != "" {
 		errMsgs.MinLength = msgs.MinLength
 	}
+	if msgs.Number != "" {
+		errMsgs.Number = msgs.Number
+	}
+	if msgs.Min != "" {
+		errMsgs.Min = msgs.Min
+	}
+	if msgs.Max != "" {
+		errMsgs.Max = msgs.Max
+	}
+	if msgs.Range != "" {
+		errMsgs.Range = msgs.Range
+	}
+	if msgs.Time != "" {
+		errMsgs.Time = msgs.Time
+	}
+	if msgs.MinTime != "" {
+		errMsgs.MinTime = msgs.MinTime
+	}
+	if msgs.MaxTime != "" {
+		errMsgs.MaxTime = msgs.MaxTime
+	}
+	if msgs.Checked != "" {
+		errMsgs.Checked = msgs.Checked
+	}
+	if msgs.MinSelected != "" {
+		errMsgs.MinSelected = msgs.MinSelected
+	}
+	if msgs.MaxSelected != "" {
+		errMsgs.MaxSelected = msgs.MaxSelected
+	}
+	if msgs.OneOf != "" {
+		errMsgs.OneOf = msgs.OneOf
+	}
+	if msgs.FileType != "" {
+		errMsgs.FileType = msgs.FileType
+	}
+	if msgs.FileSize != "" {
+		errMsgs.FileSize = msgs.FileSize
+	}
+	if msgs.HexColor != "" {
+		errMsgs.HexColor = msgs.HexColor
+	}
+	if msgs.MaxTags != "" {
+		errMsgs.MaxTags = msgs.MaxTags
+	}
+	if msgs.Duplicated != "" {
+		errMsgs.Duplicated = msgs.Duplicated
+	}
 }
@@ -0,0 +1,88 @@
+package svalid
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+)
+
+// FileExtension defines a validator for file fields that only accepts the
+// extensions (like ".png"), ignoring the case. A nil URI is accepted.
+func FileExtension(extensions ...string) func(fyne.URI) error {
+	return func(uri fyne.URI) error {
+		if uri == nil {
+			return nil
+		}
+		ext := strings.ToLower(uri.Extension())
+		for _, e := range extensions {
+			if strings.ToLower(e) == ext {
+				return nil
+			}
+		}
+		return errors.New(errMsgs.FileType)
+	}
+}
+
+// MaxFileSize defines a validator for file fields that accepts files up
+// to max bytes. A nil URI is accepted. The files that are not local are
+// read on every call to get their size (see FileSize and MaxBytes).
+func MaxFileSize(max int64) func(fyne.URI) error {
+	return func(uri fyne.URI) error {
+		if uri == nil {
+			return nil
+		}
+		size, err := FileSize(uri)
+		if err != nil {
+			return err
+		}
+		return MaxBytes(max)(size)
+	}
+}
+
+// MaxBytes defines a validator for file sizes that accepts sizes up to
+// max bytes. It is used when the size of the file is already known.
+func MaxBytes(max int64) func(size int64) error {
+	return func(size int64) error {
+		if size > max {
+			return fmt.Errorf(errMsgs.FileSize, formatBytes(max))
+		}
+		return nil
+	}
+}
+
+// FileSize returns the size of the file, reading it if it is not a
+// local file.
+func FileSize(uri fyne.URI) (int64, error) {
+	if uri.Scheme() == "file" {
+		info, err := os.Stat(uri.Path())
+		if err != nil {
+			return 0, err
+		}
+		return info.Size(), nil
+	}
+	r, err := storage.Reader(uri)
+	if err != nil {
+		return 0, err
+	}
+	defer r.Close()
+	return io.Copy(ioutil.Discard, r)
+}
+
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for v := n / unit; v >= unit; v /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(n)/float64(div), "KMGTPE"[exp])
+}
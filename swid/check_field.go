@@ -0,0 +1,72 @@
+package swid
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// CheckField defines a check field widget.
+type CheckField struct {
+	widget.Check
+
+	focused        bool
+	onFocusChanged func(bool)
+
+	fieldKeys
+}
+
+// NewCheckField creates a new check field widget.
+func NewCheckField(text string, changed func(bool)) *CheckField {
+	c := &CheckField{}
+	c.ExtendBaseWidget(c)
+	c.Text = text
+	c.OnChanged = changed
+	return c
+}
+
+// ===============================================================
+// Implementation
+// ===============================================================
+
+// MinSize implements fyne.CanvasObject.
+func (c *CheckField) MinSize() fyne.Size {
+	c.ExtendBaseWidget(c)
+	return c.Check.MinSize()
+}
+
+// FocusGained overrides widget.Check method.
+func (c *CheckField) FocusGained() {
+	c.focused = true
+	c.Check.FocusGained()
+	if c.onFocusChanged != nil {
+		c.onFocusChanged(true)
+	}
+}
+
+// FocusLost overrides widget.Check method.
+func (c *CheckField) FocusLost() {
+	c.focused = false
+	c.Check.FocusLost()
+	if c.onFocusChanged != nil {
+		c.onFocusChanged(false)
+	}
+}
+
+// Tapped overrides widget.Check method.
+func (c *CheckField) Tapped(ev *fyne.PointEvent) {
+	if c.Disabled() {
+		return
+	}
+	if cnv := fyne.CurrentApp().Driver().CanvasForObject(c); cnv != nil {
+		cnv.Focus(c)
+	}
+	c.Check.Tapped(ev)
+}
+
+// TypedKey overrides widget.Check method.
+func (c *CheckField) TypedKey(key *fyne.KeyEvent) {
+	if c.typedNavigationKey(key.Name) {
+		return
+	}
+	c.Check.TypedKey(key)
+}
@@ -0,0 +1,277 @@
+package swid
+
+import (
+	"strconv"
+
+	"fyne.io/fyne/v2"
+)
+
+// CheckFormField defines a special check field for Forms.
+type CheckFormField struct {
+	toggleFormField
+}
+
+// SwitchFormField defines a special on/off switch field for Forms.
+type SwitchFormField struct {
+	toggleFormField
+}
+
+// NewCheckFormField creates a new check form field. The text is shown
+// next to the check box.
+func NewCheckFormField(label, text string, initialValue bool) *CheckFormField {
+	c := &CheckFormField{}
+	check := NewCheckField(text, nil)
+	check.Checked = initialValue
+	check.onFocusChanged = c.focusChanged
+	check.keyHandler = &c.BaseFormField
+	c.setup(c, label, text, initialValue, &checkToggle{check})
+	check.OnChanged = c.changed
+	return c
+}
+
+// NewSwitchFormField creates a new switch form field. The text is shown
+// next to the switch.
+func NewSwitchFormField(label, text string, initialValue bool) *SwitchFormField {
+	s := &SwitchFormField{}
+	sw := NewSwitchField(text, nil)
+	sw.Checked = initialValue
+	sw.onFocusChanged = s.focusChanged
+	sw.keyHandler = &s.BaseFormField
+	s.setup(s, label, text, initialValue, &switchToggle{sw})
+	sw.OnChanged = s.changed
+	return s
+}
+
+// toggle is the internal widget of a toggleFormField.
+type toggle interface {
+	widget() fyne.Widget
+	focusable() fyne.Focusable
+	isChecked() bool
+	setChecked(bool)
+	isFocused() bool
+	setText(string)
+	setDisabled(bool)
+}
+
+// toggleFormField defines the common behavior of the check and switch
+// form fields.
+type toggleFormField struct {
+	BaseFormField
+
+	// Text is shown next to the check box or the switch.
+	Text string
+	// Validator checks the state of the field (see svalid.Checked).
+	Validator func(bool) error
+
+	OnChanged func(bool) `json:"-"`
+	OnSaved   func(bool)
+
+	toggle       toggle
+	initialValue bool
+	isRendered   bool // TODO remove when Fyne has a way to check if the widget has been renderered or not
+}
+
+func (t *toggleFormField) setup(impl fyne.Widget, label, text string, initialValue bool, tg toggle) {
+	t.ExtendBaseFormField(impl)
+	t.Label = label
+	t.Text = text
+	t.initialValue = initialValue
+	t.toggle = tg
+}
+
+// ===============================================================
+// Methods
+// ===============================================================
+
+// Checked returns the state of the field.
+func (t *toggleFormField) Checked() bool {
+	return t.toggle.isChecked()
+}
+
+// SetChecked sets the state of the field.
+func (t *toggleFormField) SetChecked(checked bool) {
+	t.toggle.setChecked(checked)
+	t.Refresh()
+}
+
+// Reset resets the field to the initial value.
+func (t *toggleFormField) Reset() {
+	t.resetDirty()
+	t.SetChecked(t.initialValue)
+	t.validationError = nil
+	if t.Validator != nil {
+		t.validationError = t.Validator(t.Checked())
+	}
+	t.Refresh()
+	t.didChange()
+}
+
+// Save triggers the OnSaved callback.
+func (t *toggleFormField) Save() {
+	if t.OnSaved != nil {
+		t.OnSaved(t.Checked())
+	}
+}
+
+func (t *toggleFormField) draftValue() (string, bool) {
+	return strconv.FormatBool(t.Checked()), true
+}
+
+func (t *toggleFormField) restoreDraftValue(v string) {
+	checked, err := strconv.ParseBool(v)
+	if err != nil {
+		return
+	}
+	t.SetChecked(checked)
+	t.Validate()
+	t.didChange()
+}
+
+// ValidationError returns the underlying validation error.
+func (t *toggleFormField) ValidationError() error {
+	if t.Validator != nil {
+		// TODO remove when Fyne has a way to check if the widget has been renderered or not
+		// means that this was called before CreateRenderer so create it by refreshing.
+		if !t.isRendered {
+			t.Refresh()
+		}
+		return t.validationError
+	}
+	return nil
+}
+
+// Validate validates the field.
+func (t *toggleFormField) Validate() error {
+	t.validationPending = false
+	if t.Validator != nil {
+		err := t.Validator(t.Checked())
+		if t.validationError != err {
+			t.validationError = err
+			t.Refresh()
+		}
+		return t.validationError
+	}
+	return nil
+}
+
+func (t *toggleFormField) focusTarget() fyne.Focusable {
+	return t.toggle.focusable()
+}
+
+func (t *toggleFormField) changed(checked bool) {
+	t.dirty = true
+	if t.Validator != nil && t.shouldRunValidator() {
+		t.validationError = t.Validator(checked)
+	}
+	if t.OnChanged != nil {
+		t.OnChanged(checked)
+	}
+	t.didChange()
+	t.Refresh()
+}
+
+func (t *toggleFormField) focusChanged(focused bool) {
+	if !focused {
+		t.blurred = true
+		if old := t.validationError; t.validateOnBlur() && t.Validate() != old {
+			// notify the form about the validation change.
+			t.didChange()
+		}
+	}
+	t.Refresh()
+}
+
+// ===============================================================
+// Renderer
+// ===============================================================
+
+// CreateRenderer implements fyne.Widget.
+func (t *toggleFormField) CreateRenderer() fyne.WidgetRenderer {
+	if t.Validator != nil {
+		t.validationError = t.Validator(t.Checked())
+	}
+
+	isFieldEmpty := func() bool {
+		return false // the label is always stacked above the check box or switch.
+	}
+
+	updateInternalField := func() {
+		t.toggle.setText(t.Text)
+		t.toggle.setDisabled(t.Disabled())
+	}
+
+	r := t.createPristineRenderer(
+		t.Label, t.Hint, t.toggle.widget(),
+		isFieldEmpty, t.toggle.isFocused,
+		updateInternalField,
+	)
+
+	t.isRendered = true // TODO remove when Fyne has a way to check if the widget has been renderered or not
+
+	return r
+}
+
+// ===============================================================
+// Private helpers
+// ===============================================================
+
+// checkToggle adapts a CheckField to the toggle interface.
+type checkToggle struct {
+	check *CheckField
+}
+
+func (c *checkToggle) widget() fyne.Widget       { return c.check }
+func (c *checkToggle) focusable() fyne.Focusable { return c.check }
+func (c *checkToggle) isChecked() bool           { return c.check.Checked }
+func (c *checkToggle) isFocused() bool           { return c.check.focused }
+
+func (c *checkToggle) setChecked(checked bool) {
+	// avoid calling OnChanged, like the other form fields setters.
+	c.check.Checked = checked
+	c.check.Refresh()
+}
+
+func (c *checkToggle) setText(text string) {
+	if c.check.Text != text {
+		c.check.Text = text
+		c.check.Refresh()
+	}
+}
+
+func (c *checkToggle) setDisabled(disabled bool) {
+	if disabled {
+		c.check.Disable()
+	} else {
+		c.check.Enable()
+	}
+}
+
+// switchToggle adapts a SwitchField to the toggle interface.
+type switchToggle struct {
+	sw *SwitchField
+}
+
+func (s *switchToggle) widget() fyne.Widget       { return s.sw }
+func (s *switchToggle) focusable() fyne.Focusable { return s.sw }
+func (s *switchToggle) isChecked() bool           { return s.sw.Checked }
+func (s *switchToggle) isFocused() bool           { return s.sw.focused }
+
+func (s *switchToggle) setChecked(checked bool) {
+	s.sw.Checked = checked
+	s.sw.Refresh()
+}
+
+func (s *switchToggle) setText(text string) {
+	if s.sw.Text != text {
+		s.sw.Text = text
+		s.sw.Refresh()
+	}
+}
+
+func (s *switchToggle) setDisabled(disabled bool) {
+	if disabled {
+		s.sw.Disable()
+	} else {
+		s.sw.Enable()
+	}
+}
@@ -0,0 +1,90 @@
+package swid
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"github.com/fpabl0/sparky-go/svalid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckFormField(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	c := NewCheckFormField("Terms", "I accept the terms", false)
+	c.Validator = svalid.Checked()
+
+	saved := true
+	c.OnSaved = func(checked bool) { saved = checked }
+	changed := 0
+	c.OnChanged = func(bool) { changed++ }
+
+	f := NewForm(1, c)
+	w := test.NewWindow(f)
+	defer w.Close()
+
+	assert.Error(t, c.ValidationError())
+	assert.False(t, f.IsValid())
+
+	test.Tap(c.toggle.widget().(*CheckField))
+	assert.True(t, c.Checked())
+	assert.Equal(t, 1, changed)
+	assert.NoError(t, c.ValidationError())
+	assert.True(t, f.IsValid())
+
+	c.Save()
+	assert.True(t, saved)
+
+	c.Reset()
+	assert.False(t, c.Checked())
+	assert.Error(t, c.ValidationError())
+	assert.False(t, f.IsValid())
+
+	c.Disable()
+	test.Tap(c.toggle.widget().(*CheckField))
+	assert.False(t, c.Checked())
+}
+
+func TestSwitchFormField(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	s := NewSwitchFormField("Notifications", "Send me emails", true)
+	sw := s.toggle.widget().(*SwitchField)
+	assert.True(t, s.Checked())
+	assert.True(t, sw.Checked)
+
+	w := test.NewWindow(s)
+	defer w.Close()
+
+	test.Tap(sw)
+	assert.False(t, s.Checked())
+
+	w.Canvas().Focus(sw)
+	assert.True(t, sw.focused)
+	sw.TypedRune(' ')
+	assert.True(t, s.Checked())
+
+	s.SetChecked(false)
+	assert.False(t, sw.Checked)
+	s.Reset()
+	assert.True(t, s.Checked())
+
+	s.Text = "Send me SMS"
+	s.Refresh()
+	assert.Equal(t, "Send me SMS", sw.Text)
+	assert.Greater(t, sw.MinSize().Width, float32(0))
+}
+
+func TestCheckFormField_Navigation(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	c := NewCheckFormField("Terms", "I accept", false)
+	s := NewSwitchFormField("News", "Subscribe", false)
+	f := NewForm(1, c, s)
+	w := test.NewWindow(f)
+	defer w.Close()
+
+	check := c.toggle.widget().(*CheckField)
+	w.Canvas().Focus(check)
+	check.TypedKey(&fyne.KeyEvent{Name: fyne.KeyTab})
+	assert.Equal(t, s.toggle.widget(), w.Canvas().Focused())
+}
@@ -0,0 +1,134 @@
+package swid
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// chip shows a text with a button to remove it, like the selected
+// options of a MultiSelectFormField.
+type chip struct {
+	widget.BaseWidget
+	text      string
+	removable bool
+	onRemoved func()
+
+	removeButton *adornmentButton
+}
+
+func newChip(text string, onRemoved func()) *chip {
+	c := &chip{text: text, removable: true, onRemoved: onRemoved}
+	c.ExtendBaseWidget(c)
+	c.removeButton = newAdornmentButton(theme.CancelIcon(), func() {
+		if c.removable && c.onRemoved != nil {
+			c.onRemoved()
+		}
+	})
+	return c
+}
+
+func (c *chip) setRemovable(removable bool) {
+	if c.removable != removable {
+		c.removable = removable
+		c.Refresh()
+	}
+}
+
+func (c *chip) CreateRenderer() fyne.WidgetRenderer {
+	c.ExtendBaseWidget(c)
+	r := &chipRenderer{
+		bg:     canvas.NewRectangle(theme.ShadowColor()),
+		label:  canvas.NewText(c.text, theme.ForegroundColor()),
+		widget: c,
+	}
+	r.Refresh()
+	return r
+}
+
+type chipRenderer struct {
+	bg      *canvas.Rectangle
+	label   *canvas.Text
+	widget  *chip
+	objects []fyne.CanvasObject
+}
+
+func (r *chipRenderer) Destroy() {}
+
+func (r *chipRenderer) Layout(size fyne.Size) {
+	pad := theme.Padding()
+	r.bg.Resize(size)
+	labelMin := r.label.MinSize()
+	r.label.Move(fyne.NewPos(pad*2, (size.Height-labelMin.Height)/2))
+	r.label.Resize(labelMin)
+	if r.widget.removable {
+		iconSize := theme.IconInlineSize() * 0.75
+		r.widget.removeButton.Move(fyne.NewPos(size.Width-pad-iconSize, (size.Height-iconSize)/2))
+		r.widget.removeButton.Resize(fyne.NewSize(iconSize, iconSize))
+	}
+}
+
+func (r *chipRenderer) MinSize() fyne.Size {
+	pad := theme.Padding()
+	min := r.label.MinSize().Add(fyne.NewSize(pad*4, pad))
+	if r.widget.removable {
+		min.Width += theme.IconInlineSize()*0.75 - pad
+	}
+	return min
+}
+
+func (r *chipRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *chipRenderer) Refresh() {
+	r.bg.FillColor = theme.ShadowColor()
+	r.label.Text = r.widget.text
+	r.label.TextSize = theme.TextSize()
+	r.label.Color = theme.ForegroundColor()
+	r.objects = []fyne.CanvasObject{r.bg, r.label}
+	if r.widget.removable {
+		r.objects = append(r.objects, r.widget.removeButton)
+	}
+	r.bg.Refresh()
+	r.label.Refresh()
+	r.Layout(r.widget.Size())
+}
+
+// layoutChips places the chips in rows starting at pos, wrapping them at
+// the width. It returns the height used by the rows.
+func layoutChips(chips []fyne.CanvasObject, pos fyne.Position, width float32) float32 {
+	pad := theme.Padding()
+	x, y, rowHeight := pos.X, pos.Y, float32(0)
+	for _, c := range chips {
+		min := c.MinSize()
+		if x > pos.X && x+min.Width > pos.X+width {
+			x = pos.X
+			y += rowHeight + pad
+			rowHeight = 0
+		}
+		c.Move(fyne.NewPos(x, y))
+		c.Resize(min)
+		x += min.Width + pad
+		rowHeight = fyne.Max(rowHeight, min.Height)
+	}
+	return y + rowHeight - pos.Y
+}
+
+// chipsHeight returns the height used by the chips wrapped at the width.
+func chipsHeight(chips []fyne.CanvasObject, width float32) float32 {
+	pad := theme.Padding()
+	x, height, rowHeight := float32(0), float32(0), float32(0)
+	for _, c := range chips {
+		min := c.MinSize()
+		if x > 0 && x+min.Width > width {
+			x = 0
+			height += rowHeight + pad
+			rowHeight = 0
+		}
+		x += min.Width + pad
+		rowHeight = fyne.Max(rowHeight, min.Height)
+	}
+	return height + rowHeight
+}
@@ -0,0 +1,541 @@
+package swid
+
+import (
+	"fmt"
+	"image/color"
+	"math"
+	"strconv"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/fpabl0/sparky-go/svalid"
+)
+
+// ColorMode defines the channels edited by the picker of a
+// ColorFormField.
+type ColorMode int
+
+// ColorMode options
+const (
+	// ColorModeRGB edits the red, green and blue channels (0-255).
+	ColorModeRGB ColorMode = iota
+	// ColorModeHSV edits the hue (0-360), the saturation (0-100) and the
+	// value (0-100).
+	ColorModeHSV
+)
+
+// DefaultColorPalette is the palette shown by the ColorFormField picker
+// when the field has no Palette.
+var DefaultColorPalette = []color.Color{
+	color.NRGBA{0xf4, 0x43, 0x36, 0xff}, color.NRGBA{0xe9, 0x1e, 0x63, 0xff},
+	color.NRGBA{0x9c, 0x27, 0xb0, 0xff}, color.NRGBA{0x67, 0x3a, 0xb7, 0xff},
+	color.NRGBA{0x3f, 0x51, 0xb5, 0xff}, color.NRGBA{0x21, 0x96, 0xf3, 0xff},
+	color.NRGBA{0x00, 0xbc, 0xd4, 0xff}, color.NRGBA{0x00, 0x96, 0x88, 0xff},
+	color.NRGBA{0x4c, 0xaf, 0x50, 0xff}, color.NRGBA{0x8b, 0xc3, 0x4a, 0xff},
+	color.NRGBA{0xff, 0xeb, 0x3b, 0xff}, color.NRGBA{0xff, 0xc1, 0x07, 0xff},
+	color.NRGBA{0xff, 0x98, 0x00, 0xff}, color.NRGBA{0x79, 0x55, 0x48, 0xff},
+	color.NRGBA{0x9e, 0x9e, 0x9e, 0xff}, color.NRGBA{0x00, 0x00, 0x00, 0xff},
+}
+
+const colorPaletteColumns = 8
+
+// ColorFormField defines a form field for colors. The color is typed as a
+// hexadecimal text (like "#1e88e5") and it is shown in a swatch before the
+// text. The picker shows a palette and the channels of the color.
+// The Validator receives the text.
+type ColorFormField struct {
+	TextFormField
+
+	// Palette are the colors shown by the picker, DefaultColorPalette by
+	// default.
+	Palette []color.Color
+	// AllowAlpha allows colors with transparency, typed as "#rrggbbaa".
+	AllowAlpha bool
+	// Mode defines the channels edited by the picker.
+	Mode ColorMode
+
+	// OnColorSaved is called by Save with the color, which is nil if the
+	// field is empty.
+	OnColorSaved func(c color.Color)
+
+	swatch       *colorSwatch
+	pickerButton *adornmentButton
+	popUp        *widget.PopUp
+	editor       *colorEditor
+}
+
+// NewColorFormField creates a new color form field. A nil initial value
+// leaves the field empty.
+func NewColorFormField(label string, initialValue color.Color) *ColorFormField {
+	c := &ColorFormField{}
+	c.ExtendBaseFormField(c)
+	c.Label = label
+	c.Wrapping = fyne.TextTruncate
+	if initialValue != nil {
+		c.initialText = colorToHex(initialValue, true)
+	}
+	c.setupTextField()
+	c.textField.acceptRune = c.acceptRune
+	c.valueValidator = c.validateText
+	c.swatch = newColorSwatch(nil, c.ShowPicker)
+	c.swatch.setColor(c.Color())
+	c.extraLeading = []fyne.CanvasObject{c.swatch}
+	c.pickerButton = newAdornmentButton(theme.MenuDropDownIcon(), c.ShowPicker)
+	c.extraTrailing = []fyne.CanvasObject{c.pickerButton}
+
+	onChanged := c.textField.OnChanged
+	c.textField.OnChanged = func(s string) {
+		c.swatch.setColor(c.Color())
+		onChanged(s)
+	}
+	return c
+}
+
+// ===============================================================
+// Methods
+// ===============================================================
+
+// Color returns the current color. It returns nil if the text is empty or
+// it is not a valid color.
+func (c *ColorFormField) Color() color.Color {
+	if c.validateHex(c.textField.Text) != nil {
+		return nil
+	}
+	v, ok := parseHexColor(c.textField.Text)
+	if !ok {
+		return nil
+	}
+	return v
+}
+
+// SetColor sets the color, formatted as a hexadecimal text. A nil color
+// clears the field.
+func (c *ColorFormField) SetColor(v color.Color) {
+	if v == nil {
+		c.SetText("")
+		return
+	}
+	c.SetText(colorToHex(v, c.AllowAlpha))
+}
+
+// IsEmpty returns true if the field has no color.
+func (c *ColorFormField) IsEmpty() bool {
+	return c.textField.Text == ""
+}
+
+// Save triggers the OnSaved and OnColorSaved callbacks.
+func (c *ColorFormField) Save() {
+	c.TextFormField.Save()
+	if c.OnColorSaved != nil {
+		c.OnColorSaved(c.Color())
+	}
+}
+
+// ShowPicker shows the palette and the channels of the color below the
+// field.
+func (c *ColorFormField) ShowPicker() {
+	if c.Disabled() {
+		return
+	}
+	cv := fyne.CurrentApp().Driver().CanvasForObject(c.adorned)
+	if cv == nil {
+		return
+	}
+	c.editor = newColorEditor(c)
+	c.popUp = widget.NewPopUp(c.editor.content, cv)
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(c.adorned)
+	c.popUp.ShowAtPosition(pos.Add(fyne.NewPos(0, c.adorned.Size().Height)))
+}
+
+// HidePicker hides the picker, if it is shown.
+func (c *ColorFormField) HidePicker() {
+	if c.popUp != nil {
+		c.popUp.Hide()
+		c.popUp = nil
+		c.editor = nil
+	}
+}
+
+// validateText checks the text is a valid color, and then runs the
+// Validator.
+func (c *ColorFormField) validateText(text string) error {
+	if err := c.validateHex(text); err != nil {
+		return err
+	}
+	if c.Validator != nil {
+		return c.Validator(text)
+	}
+	return nil
+}
+
+func (c *ColorFormField) validateHex(text string) error {
+	return svalid.HexColor(c.AllowAlpha)(text)
+}
+
+// acceptRune only accepts the hexadecimal digits, and the "#" at the
+// start.
+func (c *ColorFormField) acceptRune(text string, r rune, pos int) bool {
+	if r == '#' {
+		return pos == 0 && !strings.HasPrefix(text, "#")
+	}
+	maxDigits := 6
+	if c.AllowAlpha {
+		maxDigits = 8
+	}
+	return isHexDigit(r) && len(strings.TrimPrefix(text, "#")) < maxDigits
+}
+
+// pickerColor returns the color shown by the picker, which is the current
+// color or black if the field is empty.
+func (c *ColorFormField) pickerColor() color.NRGBA {
+	if v := c.Color(); v != nil {
+		return toNRGBA(v)
+	}
+	return color.NRGBA{A: 0xff}
+}
+
+// ===============================================================
+// Color editor
+// ===============================================================
+
+// colorEditor is the content of the ColorFormField picker: the palette,
+// the mode and an entry for each channel of the color.
+type colorEditor struct {
+	field    *ColorFormField
+	palette  []*colorSwatch
+	mode     *widget.RadioGroup
+	labels   []*widget.Label
+	channels []*TextField
+	// updating is set while the entries are filled with the color, so
+	// their changes are not applied to the field.
+	updating bool
+	content  fyne.CanvasObject
+}
+
+func newColorEditor(field *ColorFormField) *colorEditor {
+	e := &colorEditor{field: field}
+
+	palette := field.Palette
+	if palette == nil {
+		palette = DefaultColorPalette
+	}
+	grid := container.NewGridWithColumns(colorPaletteColumns)
+	for _, p := range palette {
+		p := p
+		s := newColorSwatch(p, func() { e.selectColor(p) })
+		e.palette = append(e.palette, s)
+		grid.Add(s)
+	}
+
+	e.mode = widget.NewRadioGroup([]string{"RGB", "HSV"}, nil)
+	e.mode.Horizontal = true
+	e.mode.Required = true
+
+	n := 3
+	if field.AllowAlpha {
+		n = 4
+	}
+	channels := container.NewGridWithColumns(n)
+	for i := 0; i < n; i++ {
+		label := widget.NewLabel("")
+		entry := NewTextField()
+		entry.OnChanged = func(string) { e.applyChannels() }
+		// the form handles the shortcuts (like undo and redo).
+		entry.onTypedShortcut = field.typedShortcut
+		e.labels = append(e.labels, label)
+		e.channels = append(e.channels, entry)
+		channels.Add(container.NewBorder(nil, nil, label, nil, entry))
+	}
+
+	e.mode.OnChanged = func(mode string) {
+		field.Mode = ColorModeRGB
+		if mode == "HSV" {
+			field.Mode = ColorModeHSV
+		}
+		e.update()
+	}
+	e.mode.SetSelected(e.mode.Options[field.Mode])
+	e.update()
+
+	e.content = container.NewVBox(grid, e.mode, channels)
+	return e
+}
+
+// selectColor sets the color chosen in the palette.
+func (e *colorEditor) selectColor(c color.Color) {
+	e.field.SetColor(c)
+	e.update()
+}
+
+// update fills the entries with the color of the field, and marks the
+// palette color.
+func (e *colorEditor) update() {
+	e.updating = true
+	defer func() { e.updating = false }()
+
+	v := e.field.pickerColor()
+	values := []int{int(v.R), int(v.G), int(v.B)}
+	names := []string{"R", "G", "B"}
+	if e.field.Mode == ColorModeHSV {
+		h, s, val := rgbToHSV(v)
+		values = []int{int(math.Round(h)), int(math.Round(s)), int(math.Round(val))}
+		names = []string{"H", "S", "V"}
+	}
+	values = append(values, int(v.A))
+	names = append(names, "A")
+	for i, entry := range e.channels {
+		e.labels[i].SetText(names[i])
+		entry.SetText(strconv.Itoa(values[i]))
+	}
+
+	hex := colorToHex(v, true)
+	for _, s := range e.palette {
+		s.setSelected(e.field.Color() != nil && colorToHex(s.color, true) == hex)
+	}
+}
+
+// applyChannels sets the color typed in the entries, if they are valid.
+func (e *colorEditor) applyChannels() {
+	if e.updating {
+		return
+	}
+	values := make([]int, len(e.channels))
+	for i, entry := range e.channels {
+		v, err := strconv.Atoi(strings.TrimSpace(entry.Text))
+		if err != nil || v < 0 {
+			return
+		}
+		values[i] = v
+	}
+	var c color.NRGBA
+	if e.field.Mode == ColorModeHSV {
+		if values[0] > 360 || values[1] > 100 || values[2] > 100 {
+			return
+		}
+		c = hsvToRGB(float64(values[0]), float64(values[1]), float64(values[2]))
+	} else {
+		if values[0] > 255 || values[1] > 255 || values[2] > 255 {
+			return
+		}
+		c = color.NRGBA{R: uint8(values[0]), G: uint8(values[1]), B: uint8(values[2])}
+	}
+	c.A = 0xff
+	if len(values) > 3 {
+		if values[3] > 255 {
+			return
+		}
+		c.A = uint8(values[3])
+	}
+	e.field.SetColor(c)
+}
+
+// ===============================================================
+// Color swatch
+// ===============================================================
+
+// colorSwatch shows a color over a checkerboard, so the transparency is
+// visible.
+type colorSwatch struct {
+	widget.BaseWidget
+	color    color.Color
+	selected bool
+	onTapped func()
+}
+
+func newColorSwatch(c color.Color, onTapped func()) *colorSwatch {
+	s := &colorSwatch{color: c, onTapped: onTapped}
+	s.ExtendBaseWidget(s)
+	return s
+}
+
+func (s *colorSwatch) setColor(c color.Color) {
+	s.color = c
+	s.Refresh()
+}
+
+func (s *colorSwatch) setSelected(selected bool) {
+	if s.selected != selected {
+		s.selected = selected
+		s.Refresh()
+	}
+}
+
+// Tapped implements fyne.Tappable.
+func (s *colorSwatch) Tapped(*fyne.PointEvent) {
+	if s.onTapped != nil {
+		s.onTapped()
+	}
+}
+
+func (s *colorSwatch) CreateRenderer() fyne.WidgetRenderer {
+	s.ExtendBaseWidget(s)
+	r := &colorSwatchRenderer{
+		border: canvas.NewRectangle(color.Transparent),
+		fill:   canvas.NewRectangle(color.Transparent),
+		widget: s,
+	}
+	for i := range r.checks {
+		r.checks[i] = canvas.NewRectangle(color.White)
+	}
+	r.Refresh()
+	return r
+}
+
+type colorSwatchRenderer struct {
+	border *canvas.Rectangle
+	checks [4]*canvas.Rectangle
+	fill   *canvas.Rectangle
+	widget *colorSwatch
+}
+
+func (r *colorSwatchRenderer) Destroy() {}
+
+func (r *colorSwatchRenderer) Layout(size fyne.Size) {
+	r.border.Resize(size)
+	inset := theme.InputBorderSize() * 2
+	inner := fyne.NewSize(size.Width-inset*2, size.Height-inset*2)
+	r.fill.Move(fyne.NewPos(inset, inset))
+	r.fill.Resize(inner)
+	half := fyne.NewSize(inner.Width/2, inner.Height/2)
+	for i, c := range r.checks {
+		c.Move(fyne.NewPos(inset+float32(i%2)*half.Width, inset+float32(i/2)*half.Height))
+		c.Resize(half)
+	}
+}
+
+func (r *colorSwatchRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(theme.IconInlineSize(), theme.IconInlineSize())
+}
+
+func (r *colorSwatchRenderer) Objects() []fyne.CanvasObject {
+	objects := []fyne.CanvasObject{r.border}
+	for _, c := range r.checks {
+		objects = append(objects, c)
+	}
+	return append(objects, r.fill)
+}
+
+func (r *colorSwatchRenderer) Refresh() {
+	s := r.widget
+	r.border.FillColor = theme.ShadowColor()
+	if s.selected {
+		r.border.FillColor = theme.PrimaryColor()
+	}
+	for i, c := range r.checks {
+		c.FillColor = color.White
+		if i == 1 || i == 2 {
+			c.FillColor = color.NRGBA{0xcc, 0xcc, 0xcc, 0xff}
+		}
+		c.Hidden = s.color == nil
+	}
+	r.fill.FillColor = s.color
+	if s.color == nil {
+		r.fill.FillColor = theme.InputBackgroundColor()
+	}
+	r.Layout(s.Size())
+	canvas.Refresh(s)
+}
+
+// ===============================================================
+// Private helpers
+// ===============================================================
+
+func toNRGBA(c color.Color) color.NRGBA {
+	return color.NRGBAModel.Convert(c).(color.NRGBA)
+}
+
+// colorToHex formats the color as "#rrggbb", adding the alpha if it is
+// allowed and the color is not opaque.
+func colorToHex(c color.Color, allowAlpha bool) string {
+	v := toNRGBA(c)
+	if allowAlpha && v.A != 0xff {
+		return fmt.Sprintf("#%02x%02x%02x%02x", v.R, v.G, v.B, v.A)
+	}
+	return fmt.Sprintf("#%02x%02x%02x", v.R, v.G, v.B)
+}
+
+// parseHexColor parses colors like "#1e88e5", "#fff" or "#1e88e580".
+func parseHexColor(s string) (color.NRGBA, bool) {
+	digits := strings.TrimPrefix(s, "#")
+	if len(digits) == 3 || len(digits) == 4 {
+		expanded := make([]byte, 0, len(digits)*2)
+		for i := 0; i < len(digits); i++ {
+			expanded = append(expanded, digits[i], digits[i])
+		}
+		digits = string(expanded)
+	}
+	if len(digits) == 6 {
+		digits += "ff"
+	}
+	if len(digits) != 8 {
+		return color.NRGBA{}, false
+	}
+	v, err := strconv.ParseUint(digits, 16, 32)
+	if err != nil {
+		return color.NRGBA{}, false
+	}
+	return color.NRGBA{R: uint8(v >> 24), G: uint8(v >> 16), B: uint8(v >> 8), A: uint8(v)}, true
+}
+
+// rgbToHSV returns the hue (0-360), the saturation (0-100) and the value
+// (0-100) of the color.
+func rgbToHSV(c color.NRGBA) (h, s, v float64) {
+	r, g, b := float64(c.R)/255, float64(c.G)/255, float64(c.B)/255
+	max := math.Max(r, math.Max(g, b))
+	min := math.Min(r, math.Min(g, b))
+	delta := max - min
+	switch {
+	case delta == 0:
+		h = 0
+	case max == r:
+		h = math.Mod((g-b)/delta, 6)
+	case max == g:
+		h = (b-r)/delta + 2
+	default:
+		h = (r-g)/delta + 4
+	}
+	h *= 60
+	if h < 0 {
+		h += 360
+	}
+	if max > 0 {
+		s = delta / max
+	}
+	return h, s * 100, max * 100
+}
+
+// hsvToRGB returns the opaque color of the hue (0-360), the saturation
+// (0-100) and the value (0-100).
+func hsvToRGB(h, s, v float64) color.NRGBA {
+	s, v = s/100, v/100
+	c := v * s
+	x := c * (1 - math.Abs(math.Mod(h/60, 2)-1))
+	m := v - c
+	var r, g, b float64
+	switch {
+	case h < 60:
+		r, g, b = c, x, 0
+	case h < 120:
+		r, g, b = x, c, 0
+	case h < 180:
+		r, g, b = 0, c, x
+	case h < 240:
+		r, g, b = 0, x, c
+	case h < 300:
+		r, g, b = x, 0, c
+	default:
+		r, g, b = c, 0, x
+	}
+	channel := func(f float64) uint8 {
+		return uint8(math.Round((f + m) * 255))
+	}
+	return color.NRGBA{R: channel(r), G: channel(g), B: channel(b), A: 0xff}
+}
+
+func isHexDigit(r rune) bool {
+	return r >= '0' && r <= '9' || r >= 'a' && r <= 'f' || r >= 'A' && r <= 'F'
+}
@@ -0,0 +1,111 @@
+package swid
+
+import (
+	"image/color"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestColorFormField(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	c := NewColorFormField("Color", color.NRGBA{0x1e, 0x88, 0xe5, 0xff})
+	var saved color.Color
+	c.OnColorSaved = func(v color.Color) { saved = v }
+
+	form := NewForm(1, c)
+	w := test.NewWindow(form)
+	w.Resize(fyne.NewSize(400, 300))
+	defer w.Close()
+
+	assert.Equal(t, "#1e88e5", c.Text())
+	assert.Equal(t, color.NRGBA{0x1e, 0x88, 0xe5, 0xff}, c.Color())
+	assert.Equal(t, c.Color(), c.swatch.color)
+
+	c.SetText("")
+	test.Type(c.textField, "#12g34z5")
+	assert.Equal(t, "#12345", c.Text())
+	assert.Nil(t, c.Color())
+	assert.EqualError(t, c.Validate(), "Invalid color")
+	assert.False(t, form.IsValid())
+
+	test.Type(c.textField, "67")
+	assert.Equal(t, "#123456", c.Text())
+	assert.NoError(t, c.Validate())
+	assert.Equal(t, color.NRGBA{0x12, 0x34, 0x56, 0xff}, c.swatch.color)
+
+	c.SetText("#fff")
+	assert.Equal(t, color.NRGBA{0xff, 0xff, 0xff, 0xff}, c.Color())
+	c.Save()
+	assert.Equal(t, color.NRGBA{0xff, 0xff, 0xff, 0xff}, saved)
+
+	c.Reset()
+	assert.Equal(t, "#1e88e5", c.Text())
+}
+
+func TestColorFormField_Alpha(t *testing.T) {
+	c := NewColorFormField("Color", nil)
+	assert.True(t, c.IsEmpty())
+	assert.Nil(t, c.Color())
+
+	// the alpha digits are not accepted
+	c.SetText("#11223380")
+	assert.Equal(t, "#112233", c.Text())
+	c.SetText("#1238")
+	assert.EqualError(t, c.Validate(), "Invalid color")
+
+	c.AllowAlpha = true
+	assert.NoError(t, c.Validate())
+	c.SetText("#11223380")
+	assert.Equal(t, color.NRGBA{0x11, 0x22, 0x33, 0x80}, c.Color())
+
+	c.SetColor(color.NRGBA{0xff, 0, 0, 0xff})
+	assert.Equal(t, "#ff0000", c.Text())
+}
+
+func TestColorFormField_Picker(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	c := NewColorFormField("Color", nil)
+	c.AllowAlpha = true
+	w := test.NewWindow(c)
+	w.Resize(fyne.NewSize(400, 300))
+	defer w.Close()
+
+	test.Tap(c.pickerButton)
+	assert.NotNil(t, w.Canvas().Overlays().Top())
+	e := c.editor
+	assert.Len(t, e.palette, len(DefaultColorPalette))
+	assert.Len(t, e.channels, 4)
+
+	test.Tap(e.palette[5])
+	assert.Equal(t, "#2196f3", c.Text())
+	assert.True(t, e.palette[5].selected)
+	assert.Equal(t, "33", e.channels[0].Text)
+	assert.Equal(t, "150", e.channels[1].Text)
+
+	e.channels[3].SetText("128")
+	assert.Equal(t, "#2196f380", c.Text())
+
+	e.mode.SetSelected("HSV")
+	assert.Equal(t, "H", e.labels[0].Text)
+	assert.Equal(t, "207", e.channels[0].Text)
+	e.channels[0].SetText("0")
+	e.channels[1].SetText("100")
+	e.channels[2].SetText("100")
+	assert.Equal(t, "#ff000080", c.Text())
+
+	c.HidePicker()
+	assert.Nil(t, w.Canvas().Overlays().Top())
+}
+
+func TestHSVConversion(t *testing.T) {
+	for _, c := range []color.NRGBA{
+		{0xff, 0, 0, 0xff}, {0, 0xff, 0, 0xff}, {0x21, 0x96, 0xf3, 0xff}, {0x80, 0x80, 0x80, 0xff},
+	} {
+		h, s, v := rgbToHSV(c)
+		assert.Equal(t, c, hsvToRGB(h, s, v))
+	}
+}
@@ -0,0 +1,478 @@
+package swid
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/fpabl0/sparky-go/svalid"
+)
+
+// Default layouts of the date and time form fields (see time.Parse).
+const (
+	DefaultDateLayout     = "2006-01-02"
+	DefaultTimeLayout     = "15:04"
+	DefaultDateTimeLayout = "2006-01-02 15:04"
+)
+
+// DateFormField defines a form field for dates, with a calendar picker.
+type DateFormField struct {
+	timeValueField
+}
+
+// TimeFormField defines a form field for times, with an hour and minute
+// picker.
+type TimeFormField struct {
+	timeValueField
+}
+
+// DateTimeFormField defines a form field for dates with time, with a
+// calendar and an hour and minute picker.
+type DateTimeFormField struct {
+	timeValueField
+}
+
+// NewDateFormField creates a new date form field. A zero initial value
+// leaves the field empty.
+func NewDateFormField(label string, initialValue time.Time) *DateFormField {
+	d := &DateFormField{}
+	d.setup(d, label, DefaultDateLayout, initialValue, true, false)
+	return d
+}
+
+// NewTimeFormField creates a new time form field. A zero initial value
+// leaves the field empty.
+func NewTimeFormField(label string, initialValue time.Time) *TimeFormField {
+	t := &TimeFormField{}
+	t.setup(t, label, DefaultTimeLayout, initialValue, false, true)
+	return t
+}
+
+// NewDateTimeFormField creates a new date and time form field. A zero
+// initial value leaves the field empty.
+func NewDateTimeFormField(label string, initialValue time.Time) *DateTimeFormField {
+	d := &DateTimeFormField{}
+	d.setup(d, label, DefaultDateTimeLayout, initialValue, true, true)
+	return d
+}
+
+// timeValueField defines the common behavior of the date and time form
+// fields. The text is parsed and formatted with the layout of the field,
+// and the typed text is checked against the same bounds as the picker.
+// The Validator receives the text.
+type timeValueField struct {
+	TextFormField
+
+	// Min and Max define the allowed range, a zero value is not checked.
+	// The TimeFormField only checks the clock of the bounds.
+	Min time.Time
+	Max time.Time
+	// FirstDayOfWeek is the first column of the calendar (like time.Monday
+	// for most european locales). It defaults to time.Sunday.
+	FirstDayOfWeek time.Weekday
+
+	layout       string
+	showDate     bool
+	showClock    bool
+	pickerButton *adornmentButton
+	popUp        *widget.PopUp
+	calendar     *calendar
+	hourSelect   *widget.Select
+	minuteSelect *widget.Select
+}
+
+func (f *timeValueField) setup(impl fyne.Widget, label, layout string, initialValue time.Time, date, clock bool) {
+	f.ExtendBaseFormField(impl)
+	f.Label = label
+	f.Wrapping = fyne.TextTruncate
+	f.layout = layout
+	f.showDate, f.showClock = date, clock
+	if !initialValue.IsZero() {
+		f.initialText = initialValue.Format(layout)
+	}
+	f.setupTextField()
+	f.textField.mask = layoutMask(layout)
+	f.valueValidator = f.validateText
+	f.pickerButton = newAdornmentButton(theme.MenuDropDownIcon(), f.ShowPicker)
+	f.extraTrailing = []fyne.CanvasObject{f.pickerButton}
+}
+
+// ===============================================================
+// Methods
+// ===============================================================
+
+// Time returns the current value. It returns the zero time if the text is
+// empty or it is not valid for the layout. The date of a TimeFormField
+// value is January 1, year 0.
+func (f *timeValueField) Time() time.Time {
+	t, err := f.parse(f.textField.Text)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// SetTime sets the value, formatted with the layout. A zero value clears
+// the field.
+func (f *timeValueField) SetTime(t time.Time) {
+	if t.IsZero() {
+		f.SetText("")
+		return
+	}
+	f.SetText(t.Format(f.layout))
+}
+
+// IsEmpty returns true if the field has no value.
+func (f *timeValueField) IsEmpty() bool {
+	return f.textField.Text == ""
+}
+
+// Layout returns the layout used to parse and format the value.
+func (f *timeValueField) Layout() string {
+	return f.layout
+}
+
+// SetLayout sets the layout used to parse and format the value (see
+// time.Parse). If the layout only has zero padded numeric elements (like
+// "02/01/2006 15:04"), it is also used as the mask of the field.
+func (f *timeValueField) SetLayout(layout string) {
+	value := f.Time()
+	initial, initialErr := f.parse(f.initialText)
+	f.layout = layout
+	f.textField.mask = layoutMask(layout)
+	if initialErr == nil && !initial.IsZero() {
+		f.initialText = initial.Format(layout)
+	}
+	f.SetTime(value)
+}
+
+// ShowPicker shows the calendar or the clock picker below the field.
+func (f *timeValueField) ShowPicker() {
+	if f.Disabled() {
+		return
+	}
+	c := fyne.CurrentApp().Driver().CanvasForObject(f.adorned)
+	if c == nil {
+		return
+	}
+	f.popUp = widget.NewPopUp(f.pickerContent(), c)
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(f.adorned)
+	f.popUp.ShowAtPosition(pos.Add(fyne.NewPos(0, f.adorned.Size().Height)))
+}
+
+// HidePicker hides the picker, if it is shown.
+func (f *timeValueField) HidePicker() {
+	if f.popUp != nil {
+		f.popUp.Hide()
+		f.popUp = nil
+	}
+}
+
+func (f *timeValueField) parse(text string) (time.Time, error) {
+	if text == "" {
+		return time.Time{}, nil
+	}
+	return time.ParseInLocation(f.layout, text, time.Local)
+}
+
+// bounds returns Min and Max, only with their clock for a TimeFormField.
+func (f *timeValueField) bounds() (min, max time.Time) {
+	min, max = f.Min, f.Max
+	if !f.showDate {
+		min, max = clockOf(min), clockOf(max)
+	}
+	return min, max
+}
+
+// validateText checks the text is valid for the layout and inside the
+// bounds, and then runs the Validator.
+func (f *timeValueField) validateText(text string) error {
+	min, max := f.bounds()
+	if err := svalid.TimeRange(f.layout, min, max)(text); err != nil {
+		return err
+	}
+	if f.Validator != nil {
+		return f.Validator(text)
+	}
+	return nil
+}
+
+// pickerValue returns the value shown by the picker, which is the current
+// value or today (limited to the bounds) if the field is empty.
+func (f *timeValueField) pickerValue() time.Time {
+	if v := f.Time(); !v.IsZero() {
+		return v
+	}
+	now := time.Now()
+	v := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	if !f.showDate {
+		v = clockOf(v)
+	}
+	min, max := f.bounds()
+	if !min.IsZero() && v.Before(min) {
+		v = min
+	}
+	if !max.IsZero() && v.After(max) {
+		v = max
+	}
+	return v
+}
+
+func (f *timeValueField) pickerContent() fyne.CanvasObject {
+	value := f.pickerValue()
+	content := container.NewVBox()
+	if f.showDate {
+		min, max := f.bounds()
+		f.calendar = newCalendar(value, f.Time(), min, max, f.FirstDayOfWeek)
+		f.calendar.onSelected = f.selectDate
+		content.Add(f.calendar)
+	}
+	if f.showClock {
+		f.hourSelect = widget.NewSelect(numberOptions(24), nil)
+		f.hourSelect.Selected = twoDigits(value.Hour())
+		f.hourSelect.OnChanged = f.selectClock
+		f.minuteSelect = widget.NewSelect(numberOptions(60), nil)
+		f.minuteSelect.Selected = twoDigits(value.Minute())
+		f.minuteSelect.OnChanged = f.selectClock
+		content.Add(container.NewHBox(layout.NewSpacer(), f.hourSelect,
+			widget.NewLabel(":"), f.minuteSelect, layout.NewSpacer()))
+	}
+	return content
+}
+
+// selectDate sets the date selected in the calendar, keeping the clock.
+func (f *timeValueField) selectDate(day time.Time) {
+	v := f.pickerValue()
+	f.SetTime(time.Date(day.Year(), day.Month(), day.Day(),
+		v.Hour(), v.Minute(), v.Second(), 0, time.Local))
+	if !f.showClock {
+		f.HidePicker()
+	}
+}
+
+// selectClock sets the hour and minute selected in the picker, keeping
+// the date.
+func (f *timeValueField) selectClock(string) {
+	v := f.pickerValue()
+	hour, _ := strconv.Atoi(f.hourSelect.Selected)
+	minute, _ := strconv.Atoi(f.minuteSelect.Selected)
+	f.SetTime(time.Date(v.Year(), v.Month(), v.Day(), hour, minute, 0, 0, time.Local))
+}
+
+// ===============================================================
+// Calendar
+// ===============================================================
+
+// calendar shows the days of a month, with buttons to change the month.
+// The days outside the bounds are disabled.
+type calendar struct {
+	widget.BaseWidget
+	month    time.Time
+	selected time.Time
+	min      time.Time
+	max      time.Time
+	firstDay time.Weekday
+
+	onSelected func(day time.Time)
+}
+
+func newCalendar(month, selected, min, max time.Time, firstDay time.Weekday) *calendar {
+	c := &calendar{selected: selected, min: min, max: max, firstDay: firstDay}
+	c.ExtendBaseWidget(c)
+	c.month = time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, time.Local)
+	return c
+}
+
+func (c *calendar) previousMonth() {
+	c.month = c.month.AddDate(0, -1, 0)
+	c.Refresh()
+}
+
+func (c *calendar) nextMonth() {
+	c.month = c.month.AddDate(0, 1, 0)
+	c.Refresh()
+}
+
+func (c *calendar) selectDay(day time.Time) {
+	if !c.dayEnabled(day) {
+		return
+	}
+	c.selected = day
+	if c.onSelected != nil {
+		c.onSelected(day)
+	}
+	c.Refresh()
+}
+
+// dayEnabled returns true if some time of the day is inside the bounds.
+func (c *calendar) dayEnabled(day time.Time) bool {
+	if !c.min.IsZero() && !day.AddDate(0, 0, 1).After(c.min) {
+		return false
+	}
+	return c.max.IsZero() || !day.After(c.max)
+}
+
+func (c *calendar) isSelected(day time.Time) bool {
+	return !c.selected.IsZero() && c.selected.Year() == day.Year() &&
+		c.selected.YearDay() == day.YearDay()
+}
+
+// weekdays returns the weekdays in the order of the columns.
+func (c *calendar) weekdays() []time.Weekday {
+	days := make([]time.Weekday, 7)
+	for i := range days {
+		days[i] = (c.firstDay + time.Weekday(i)) % 7
+	}
+	return days
+}
+
+func (c *calendar) CreateRenderer() fyne.WidgetRenderer {
+	c.ExtendBaseWidget(c)
+	r := &calendarRenderer{calendar: c, content: container.NewVBox()}
+	r.Refresh()
+	return r
+}
+
+type calendarRenderer struct {
+	calendar *calendar
+	content  *fyne.Container
+	days     []*widget.Button
+}
+
+func (r *calendarRenderer) Destroy() {}
+
+func (r *calendarRenderer) Layout(size fyne.Size) {
+	r.content.Resize(size)
+}
+
+func (r *calendarRenderer) MinSize() fyne.Size {
+	return r.content.MinSize()
+}
+
+func (r *calendarRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.content}
+}
+
+func (r *calendarRenderer) Refresh() {
+	c := r.calendar
+	title := widget.NewLabelWithStyle(c.month.Format("January 2006"),
+		fyne.TextAlignCenter, fyne.TextStyle{Bold: true})
+	header := container.NewBorder(nil, nil,
+		widget.NewButtonWithIcon("", theme.NavigateBackIcon(), c.previousMonth),
+		widget.NewButtonWithIcon("", theme.NavigateNextIcon(), c.nextMonth),
+		title)
+
+	grid := container.NewGridWithColumns(7)
+	for _, d := range c.weekdays() {
+		grid.Add(widget.NewLabelWithStyle(d.String()[:2], fyne.TextAlignCenter, fyne.TextStyle{}))
+	}
+	for i := (int(c.month.Weekday()) - int(c.firstDay) + 7) % 7; i > 0; i-- {
+		grid.Add(layout.NewSpacer())
+	}
+	r.days = r.days[:0]
+	for day := c.month; day.Month() == c.month.Month(); day = day.AddDate(0, 0, 1) {
+		day := day
+		b := widget.NewButton(strconv.Itoa(day.Day()), func() { c.selectDay(day) })
+		if c.isSelected(day) {
+			b.Importance = widget.HighImportance
+		}
+		if !c.dayEnabled(day) {
+			b.Disable()
+		}
+		r.days = append(r.days, b)
+		grid.Add(b)
+	}
+	r.content.Objects = []fyne.CanvasObject{header, grid}
+	r.content.Refresh()
+}
+
+// ===============================================================
+// Private helpers
+// ===============================================================
+
+// paddedLayoutElements are the numeric elements of a time layout that
+// are always formatted with the same number of digits.
+var paddedLayoutElements = []string{"2006", "002", "01", "02", "03", "04", "05", "06", "15"}
+
+// layoutMask returns the mask for a time layout, or nil if the layout has
+// elements that are not numeric (like "Jan" or "PM") or that are not
+// zero-padded (like "1" or "_2"), as their length depends on the value.
+func layoutMask(layout string) []rune {
+	mask := make([]rune, 0, len(layout))
+	for i := 0; i < len(layout); {
+		n := paddedLayoutElementLen(layout, i)
+		if n > 0 {
+			for k := 0; k < n; k++ {
+				mask = append(mask, '9')
+			}
+			i += n
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(layout[i:])
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r):
+			return nil
+		case r == '_' && strings.HasPrefix(layout[i+1:], "2") && !strings.HasPrefix(layout[i+1:], "2006"):
+			// "_2" or "__2"
+			return nil
+		case strings.ContainsRune(`*\[]<>|`, r):
+			mask = append(mask, '\\', r)
+		default:
+			mask = append(mask, r)
+		}
+		i += size
+	}
+	return mask
+}
+
+// paddedLayoutElementLen returns the length of the zero-padded numeric
+// element that starts at the index i of the layout, or 0 if there is none.
+func paddedLayoutElementLen(layout string, i int) int {
+	if i > 0 && (layout[i-1] == '.' || layout[i-1] == ',') {
+		// fractional seconds like ".000"
+		n := 0
+		for i+n < len(layout) && layout[i+n] == '0' {
+			n++
+		}
+		if n > 0 && (i+n == len(layout) || !unicode.IsDigit(rune(layout[i+n]))) {
+			return n
+		}
+	}
+	for _, elem := range paddedLayoutElements {
+		if strings.HasPrefix(layout[i:], elem) {
+			return len(elem)
+		}
+	}
+	return 0
+}
+
+// clockOf returns the clock of t on January 1, year 0 (the date of a time
+// parsed without date elements).
+func clockOf(t time.Time) time.Time {
+	if t.IsZero() {
+		return t
+	}
+	return time.Date(0, 1, 1, t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.Local)
+}
+
+func numberOptions(n int) []string {
+	options := make([]string, n)
+	for i := range options {
+		options[i] = twoDigits(i)
+	}
+	return options
+}
+
+func twoDigits(n int) string {
+	if n < 10 {
+		return "0" + strconv.Itoa(n)
+	}
+	return strconv.Itoa(n)
+}
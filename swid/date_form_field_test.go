@@ -0,0 +1,133 @@
+package swid
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDateFormField_Typing(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	d := NewDateFormField("Birthday", time.Time{})
+	d.Min = time.Date(2000, 1, 1, 0, 0, 0, 0, time.Local)
+	assert.True(t, d.IsEmpty())
+	assert.True(t, d.Time().IsZero())
+
+	w := test.NewWindow(d)
+	defer w.Close()
+
+	w.Canvas().Focus(d.textField)
+	test.Type(d.textField, "2021a0315")
+	assert.Equal(t, "2021-03-15", d.Text())
+	assert.Equal(t, time.Date(2021, 3, 15, 0, 0, 0, 0, time.Local), d.Time())
+	assert.NoError(t, d.Validate())
+
+	d.SetText("1999-12-31")
+	assert.Error(t, d.Validate())
+	d.SetText("2021-02-30")
+	assert.Error(t, d.Validate())
+	assert.True(t, d.Time().IsZero())
+
+	d.SetLayout("02/01/2006")
+	d.SetTime(time.Date(2021, 3, 15, 0, 0, 0, 0, time.Local))
+	assert.Equal(t, "15/03/2021", d.Text())
+	assert.NoError(t, d.Validate())
+}
+
+func TestDateFormField_NonPaddedLayout(t *testing.T) {
+	d := NewDateFormField("Birthday", time.Time{})
+	d.SetLayout("1/2/2006")
+	assert.Nil(t, d.textField.mask)
+
+	w := test.NewWindow(d)
+	defer w.Close()
+
+	d.SetTime(time.Date(2021, 12, 25, 0, 0, 0, 0, time.Local))
+	assert.Equal(t, "12/25/2021", d.Text())
+	d.SetTime(time.Date(2021, 3, 5, 0, 0, 0, 0, time.Local))
+	assert.Equal(t, "3/5/2021", d.Text())
+	assert.Equal(t, time.Date(2021, 3, 5, 0, 0, 0, 0, time.Local), d.Time())
+
+	assert.Nil(t, layoutMask("_2/01/2006"))
+	assert.Nil(t, layoutMask("2006-01-_2"))
+	assert.Equal(t, []rune("99:99:99.999"), layoutMask("15:04:05.000"))
+	assert.Equal(t, []rune("9999_99_99"), layoutMask("2006_01_02"))
+}
+
+func TestDateFormField_Picker(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	initial := time.Date(2021, 3, 15, 0, 0, 0, 0, time.Local)
+	d := NewDateFormField("Date", initial)
+	d.Max = time.Date(2021, 3, 20, 0, 0, 0, 0, time.Local)
+	d.FirstDayOfWeek = time.Monday
+
+	w := test.NewWindow(d)
+	defer w.Close()
+
+	test.Tap(d.pickerButton)
+	assert.NotNil(t, d.popUp)
+	cal := d.calendar
+	assert.Equal(t, time.Monday, cal.weekdays()[0])
+	assert.Equal(t, time.Sunday, cal.weekdays()[6])
+	assert.True(t, cal.isSelected(initial))
+	assert.True(t, cal.dayEnabled(time.Date(2021, 3, 20, 0, 0, 0, 0, time.Local)))
+	assert.False(t, cal.dayEnabled(time.Date(2021, 3, 21, 0, 0, 0, 0, time.Local)))
+
+	cal.selectDay(time.Date(2021, 3, 25, 0, 0, 0, 0, time.Local))
+	assert.Equal(t, "2021-03-15", d.Text())
+
+	r := test.WidgetRenderer(cal).(*calendarRenderer)
+	assert.Len(t, r.days, 31)
+	assert.True(t, r.days[24].Disabled())
+	test.Tap(r.days[1])
+	assert.Equal(t, "2021-03-02", d.Text())
+	assert.Nil(t, d.popUp)
+
+	d.Reset()
+	assert.Equal(t, initial, d.Time())
+}
+
+func TestTimeFormField(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	tf := NewTimeFormField("Start", time.Date(2021, 3, 15, 9, 30, 0, 0, time.Local))
+	tf.Min = time.Date(2021, 1, 1, 8, 0, 0, 0, time.Local)
+	tf.Max = time.Date(2021, 1, 1, 18, 0, 0, 0, time.Local)
+	assert.Equal(t, "09:30", tf.Text())
+	assert.Equal(t, 9, tf.Time().Hour())
+	assert.NoError(t, tf.Validate())
+
+	w := test.NewWindow(tf)
+	defer w.Close()
+
+	tf.ShowPicker()
+	assert.Nil(t, tf.calendar)
+	assert.Equal(t, "09", tf.hourSelect.Selected)
+	tf.hourSelect.SetSelected("19")
+	assert.Equal(t, "19:30", tf.Text())
+	assert.Error(t, tf.Validate())
+	tf.minuteSelect.SetSelected("05")
+	tf.hourSelect.SetSelected("17")
+	assert.Equal(t, "17:05", tf.Text())
+	assert.NoError(t, tf.Validate())
+}
+
+func TestDateTimeFormField(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	d := NewDateTimeFormField("Meeting", time.Date(2021, 3, 15, 9, 30, 0, 0, time.Local))
+	assert.Equal(t, "2021-03-15 09:30", d.Text())
+
+	w := test.NewWindow(d)
+	defer w.Close()
+
+	d.ShowPicker()
+	d.calendar.selectDay(time.Date(2021, 3, 16, 0, 0, 0, 0, time.Local))
+	assert.Equal(t, "2021-03-16 09:30", d.Text())
+	assert.NotNil(t, d.popUp)
+	d.hourSelect.SetSelected("11")
+	assert.Equal(t, "2021-03-16 11:30", d.Text())
+	d.HidePicker()
+	assert.Nil(t, d.popUp)
+}
@@ -0,0 +1,97 @@
+package swid
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ErrorSummary defines a widget that lists the current validation errors
+// of a form. Tapping an error focuses the invalid field, scrolling to it
+// if it is inside a scroll container.
+type ErrorSummary struct {
+	widget.BaseWidget
+	Title string
+
+	form *Form
+}
+
+// NewErrorSummary creates a new error summary bound to the form.
+func NewErrorSummary(title string, form *Form) *ErrorSummary {
+	s := &ErrorSummary{Title: title, form: form}
+	s.ExtendBaseWidget(s)
+	form.listeners = append(form.listeners, s.Refresh)
+	return s
+}
+
+// CreateRenderer implements fyne.Widget.
+func (s *ErrorSummary) CreateRenderer() fyne.WidgetRenderer {
+	s.ExtendBaseWidget(s)
+	title := canvas.NewText(s.Title, theme.ErrorColor())
+	title.TextStyle.Bold = true
+	r := &errorSummaryRenderer{
+		widget:  s,
+		title:   title,
+		content: container.NewVBox(),
+	}
+	r.objects = []fyne.CanvasObject{r.content}
+	r.Refresh()
+	return r
+}
+
+type errorSummaryRenderer struct {
+	widget  *ErrorSummary
+	title   *canvas.Text
+	content *fyne.Container
+	objects []fyne.CanvasObject
+}
+
+func (r *errorSummaryRenderer) Destroy() {}
+
+func (r *errorSummaryRenderer) Layout(size fyne.Size) {
+	r.content.Resize(size)
+}
+
+func (r *errorSummaryRenderer) MinSize() fyne.Size {
+	if len(r.content.Objects) == 0 {
+		return fyne.NewSize(0, 0)
+	}
+	return r.content.MinSize()
+}
+
+func (r *errorSummaryRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *errorSummaryRenderer) Refresh() {
+	errs := r.widget.form.Errors()
+	if len(errs) == 0 {
+		r.content.Objects = nil
+		r.content.Refresh()
+		return
+	}
+	objects := make([]fyne.CanvasObject, 0, len(errs)+1)
+	if r.widget.Title != "" {
+		r.title.Text = r.widget.Title
+		r.title.Color = theme.ErrorColor()
+		r.title.Refresh()
+		objects = append(objects, r.title)
+	}
+	for _, fe := range errs {
+		field := fe.Field
+		text := fe.Err.Error()
+		if fe.Label != "" {
+			text = fe.Label + ": " + text
+		}
+		btn := widget.NewButtonWithIcon(text, theme.ErrorIcon(), func() {
+			focusField(field)
+		})
+		btn.Importance = widget.LowImportance
+		btn.Alignment = widget.ButtonAlignLeading
+		objects = append(objects, btn)
+	}
+	r.content.Objects = objects
+	r.content.Refresh()
+}
@@ -0,0 +1,88 @@
+package swid
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/widget"
+	"github.com/fpabl0/sparky-go/svalid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorSummary(t *testing.T) {
+	name := NewTextFormField("Name", "")
+	name.Validator = svalid.NotEmpty()
+	email := NewTextFormField("Email", "wrong")
+	email.Validator = svalid.NewGroup(svalid.NotEmpty(), svalid.MinLength(8))
+
+	fields := []FormField{name}
+	for i := 0; i < 10; i++ {
+		fields = append(fields, NewTextFormField("Other", ""))
+	}
+	fields = append(fields, email)
+	f := NewForm(1, fields...)
+	summary := NewErrorSummary("Please fix the errors", f)
+
+	scroll := container.NewVScroll(f)
+	w := test.NewWindow(container.NewBorder(summary, nil, nil, nil, scroll))
+	w.Resize(fyne.NewSize(300, 400))
+	defer w.Close()
+
+	errs := f.Errors()
+	assert.Len(t, errs, 2)
+	assert.Equal(t, "Name", errs[0].Label)
+	assert.Equal(t, email, errs[1].Field)
+
+	objects := test.WidgetRenderer(summary).(*errorSummaryRenderer).content.Objects
+	assert.Len(t, objects, 3)
+	emailBtn := objects[2].(*widget.Button)
+	assert.Equal(t, "Email: Min length must be 8", emailBtn.Text)
+
+	assert.Zero(t, scroll.Offset.Y)
+	test.Tap(emailBtn)
+	assert.Equal(t, email.textField, w.Canvas().Focused())
+	assert.NotZero(t, scroll.Offset.Y)
+
+	assert.True(t, f.FocusFirstInvalid())
+	assert.Equal(t, name.textField, w.Canvas().Focused())
+	assert.Zero(t, scroll.Offset.Y)
+
+	name.SetText("Peter")
+	email.SetText("peter@example.com")
+	assert.Empty(t, test.WidgetRenderer(summary).(*errorSummaryRenderer).content.Objects)
+	assert.False(t, f.FocusFirstInvalid())
+}
+
+func TestForm_MarkDirtyOnSubmit(t *testing.T) {
+	name := NewTextFormField("Name", "")
+	name.Validator = svalid.NotEmpty()
+	lastName := NewTextFormField("LastName", "")
+	lastName.Validator = svalid.NotEmpty()
+
+	f := NewForm(1, name, lastName)
+	f.MarkDirtyOnSubmit = true
+	submitted := false
+	submitButton := f.CreateSubmitButton("Submit", func() { submitted = true })
+	w := test.NewWindow(container.NewVBox(f, submitButton))
+	defer w.Close()
+
+	assert.False(t, submitButton.Disabled())
+	assert.False(t, name.dirty)
+
+	test.Tap(submitButton)
+	assert.False(t, submitted)
+	assert.True(t, name.dirty)
+	assert.True(t, lastName.dirty)
+	assert.Equal(t, name.textField, w.Canvas().Focused())
+
+	w.Canvas().Focus(nil)
+	r := test.WidgetRenderer(lastName).(*formFieldRenderer)
+	assert.Equal(t, svalid.NotEmpty()("").Error(), r.hint.Text)
+
+	name.SetText("Peter")
+	lastName.SetText("Parker")
+	test.Tap(submitButton)
+	assert.True(t, submitted)
+}
@@ -0,0 +1,544 @@
+package swid
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/fpabl0/sparky-go/svalid"
+)
+
+// FileFormField defines a form field to pick a file with the file dialog.
+// It shows the name of the chosen file. Files cannot be dropped onto the
+// field, since fyne has no API to receive dropped files yet, so they can
+// only be chosen with the dialog or set with SetURI.
+type FileFormField struct {
+	BaseFormField
+
+	Placeholder string
+	// Extensions filters the files shown by the dialog (like ".pdf"), and
+	// the field is invalid if the chosen file has another extension.
+	Extensions []string
+	// MaxSize is the maximum size of the file in bytes. It is not checked
+	// if it is 0. The size is read once for each chosen file.
+	MaxSize int64
+	// Validator is called after checking the extension and the size.
+	Validator func(uri fyne.URI) error
+
+	OnChanged func(uri fyne.URI) `json:"-"`
+	OnSaved   func(uri fyne.URI)
+
+	field        *filePickerField
+	uri          fyne.URI
+	initialValue fyne.URI
+	isImage      bool
+	isRendered   bool // TODO remove when Fyne has a way to check if the widget has been renderered or not
+
+	// size is the size of the chosen file, read when it is first needed.
+	size      int64
+	sizeErr   error
+	sizeKnown bool
+}
+
+// ImageFormField defines a form field to pick an image with the file
+// dialog. It shows a thumbnail of the chosen image.
+type ImageFormField struct {
+	FileFormField
+}
+
+// NewFileFormField creates a new file form field. The initial value can
+// be nil.
+func NewFileFormField(label string, initialValue fyne.URI) *FileFormField {
+	f := &FileFormField{}
+	f.setup(f, label, initialValue)
+	return f
+}
+
+// NewImageFormField creates a new image form field, that accepts png and
+// jpeg images. The initial value can be nil.
+func NewImageFormField(label string, initialValue fyne.URI) *ImageFormField {
+	f := &ImageFormField{}
+	f.isImage = true
+	f.Extensions = []string{".png", ".jpg", ".jpeg"}
+	f.setup(f, label, initialValue)
+	return f
+}
+
+func (f *FileFormField) setup(impl fyne.Widget, label string, initialValue fyne.URI) {
+	f.ExtendBaseFormField(impl)
+	f.Label = label
+	f.initialValue = initialValue
+	f.uri = initialValue
+	f.field = newFilePickerField(f)
+	f.field.keyHandler = &f.BaseFormField
+}
+
+// ===============================================================
+// Methods
+// ===============================================================
+
+// URI returns the chosen file, or nil if there is none.
+func (f *FileFormField) URI() fyne.URI {
+	return f.uri
+}
+
+// SetURI sets the chosen file. A nil URI clears the field.
+func (f *FileFormField) SetURI(uri fyne.URI) {
+	f.setURI(uri)
+	f.Refresh() // refresh the whole widget
+}
+
+// Browse opens the file dialog to choose a file.
+func (f *FileFormField) Browse() {
+	if f.Disabled() {
+		return
+	}
+	w := windowForObject(f)
+	if w == nil {
+		return
+	}
+	d := dialog.NewFileOpen(func(r fyne.URIReadCloser, err error) {
+		if err != nil || r == nil {
+			return
+		}
+		r.Close()
+		f.choose(r.URI())
+	}, w)
+	if len(f.Extensions) > 0 {
+		d.SetFilter(storage.NewExtensionFileFilter(f.Extensions))
+	}
+	d.Show()
+}
+
+// Clear removes the chosen file.
+func (f *FileFormField) Clear() {
+	if f.Disabled() || f.uri == nil {
+		return
+	}
+	f.choose(nil)
+}
+
+// Reset resets the field to the initial value.
+func (f *FileFormField) Reset() {
+	f.resetDirty()
+	f.SetURI(f.initialValue)
+	f.validationError = nil
+	if f.hasValidator() {
+		f.validationError = f.validate()
+	}
+	f.Refresh()
+	f.didChange()
+}
+
+// Save triggers the OnSaved callback.
+func (f *FileFormField) Save() {
+	if f.OnSaved != nil {
+		f.OnSaved(f.uri)
+	}
+}
+
+func (f *FileFormField) draftValue() (string, bool) {
+	if f.uri == nil {
+		return "", true
+	}
+	return f.uri.String(), true
+}
+
+func (f *FileFormField) restoreDraftValue(v string) {
+	var uri fyne.URI
+	if v != "" {
+		parsed, err := storage.ParseURI(v)
+		if err != nil {
+			return
+		}
+		uri = parsed
+	}
+	f.SetURI(uri)
+	f.Validate()
+	f.didChange()
+}
+
+// ValidationError returns the underlying validation error.
+func (f *FileFormField) ValidationError() error {
+	if f.hasValidator() {
+		// TODO remove when Fyne has a way to check if the widget has been renderered or not
+		// means that this was called before CreateRenderer so create it by refreshing.
+		if !f.isRendered {
+			f.Refresh()
+		}
+		return f.validationError
+	}
+	return nil
+}
+
+// Validate validates the field.
+func (f *FileFormField) Validate() error {
+	f.validationPending = false
+	if f.hasValidator() {
+		err := f.validate()
+		if f.validationError != err {
+			f.validationError = err
+			f.Refresh()
+		}
+		return f.validationError
+	}
+	return nil
+}
+
+func (f *FileFormField) hasValidator() bool {
+	return f.Validator != nil || len(f.Extensions) > 0 || f.MaxSize > 0
+}
+
+// setURI sets the chosen file, forgetting the size of the previous one.
+func (f *FileFormField) setURI(uri fyne.URI) {
+	f.uri = uri
+	f.size, f.sizeErr, f.sizeKnown = 0, nil, false
+	f.field.updatePreview()
+}
+
+// fileSize returns the size of the chosen file. It is read only once for
+// each chosen file, as the files that are not local have to be read
+// completely to get their size.
+func (f *FileFormField) fileSize() (int64, error) {
+	if !f.sizeKnown {
+		f.size, f.sizeErr = svalid.FileSize(f.uri)
+		f.sizeKnown = true
+	}
+	return f.size, f.sizeErr
+}
+
+// validate checks the extension and the size of the chosen file, and then
+// runs the Validator.
+func (f *FileFormField) validate() error {
+	if len(f.Extensions) > 0 {
+		if err := svalid.FileExtension(f.Extensions...)(f.uri); err != nil {
+			return err
+		}
+	}
+	if f.MaxSize > 0 && f.uri != nil {
+		size, err := f.fileSize()
+		if err != nil {
+			return err
+		}
+		if err := svalid.MaxBytes(f.MaxSize)(size); err != nil {
+			return err
+		}
+	}
+	if f.Validator != nil {
+		return f.Validator(f.uri)
+	}
+	return nil
+}
+
+// choose sets the file chosen by the user.
+func (f *FileFormField) choose(uri fyne.URI) {
+	f.dirty = true
+	f.setURI(uri)
+	if f.hasValidator() && f.shouldRunValidator() {
+		f.validationError = f.validate()
+	}
+	if f.OnChanged != nil {
+		f.OnChanged(uri)
+	}
+	f.didChange()
+	f.Refresh()
+}
+
+func (f *FileFormField) focusChanged(focused bool) {
+	if !focused {
+		f.blurred = true
+		if old := f.validationError; f.validateOnBlur() && f.Validate() != old {
+			// notify the form about the validation change.
+			f.didChange()
+		}
+	}
+	f.Refresh()
+}
+
+func (f *FileFormField) focusTarget() fyne.Focusable {
+	return f.field
+}
+
+// ===============================================================
+// Renderer
+// ===============================================================
+
+// CreateRenderer implements fyne.Widget.
+func (f *FileFormField) CreateRenderer() fyne.WidgetRenderer {
+	if f.hasValidator() {
+		f.validationError = f.validate()
+	}
+
+	isFieldEmpty := func() bool {
+		return f.uri == nil
+	}
+
+	isFieldFocused := func() bool {
+		return f.field.focused
+	}
+
+	updateInternalField := func() {
+		f.field.Refresh()
+	}
+
+	r := f.CreateBaseRenderer(
+		f.Label, f.Hint, f.field,
+		isFieldEmpty, isFieldFocused,
+		updateInternalField,
+	)
+
+	r.(*formFieldRenderer).labelBgColor = func() color.Color {
+		if f.field.hovered && !f.Disabled() {
+			return theme.HoverColor()
+		}
+		return theme.InputBackgroundColor()
+	}
+
+	f.isRendered = true // TODO remove when Fyne has a way to check if the widget has been renderered or not
+
+	return r
+}
+
+// ===============================================================
+// File picker field
+// ===============================================================
+
+// filePickerField is the internal widget of a FileFormField. It shows the
+// file name (or a thumbnail for images) and the browse and clear buttons.
+type filePickerField struct {
+	widget.BaseWidget
+	field *FileFormField
+
+	thumbnail    *canvas.Image
+	browseButton *adornmentButton
+	clearButton  *adornmentButton
+
+	hovered bool
+	focused bool
+
+	fieldKeys
+}
+
+func newFilePickerField(field *FileFormField) *filePickerField {
+	p := &filePickerField{field: field}
+	p.ExtendBaseWidget(p)
+	p.browseButton = newAdornmentButton(theme.FolderOpenIcon(), p.browse)
+	p.clearButton = newAdornmentButton(theme.CancelIcon(), field.Clear)
+	p.updatePreview()
+	return p
+}
+
+// updatePreview loads the thumbnail of the chosen image.
+func (p *filePickerField) updatePreview() {
+	if !p.field.isImage || p.field.uri == nil {
+		p.thumbnail = nil
+		return
+	}
+	p.thumbnail = canvas.NewImageFromURI(p.field.uri)
+	p.thumbnail.FillMode = canvas.ImageFillContain
+}
+
+func (p *filePickerField) browse() {
+	if c := fyne.CurrentApp().Driver().CanvasForObject(p); c != nil && !p.field.Disabled() {
+		c.Focus(p)
+	}
+	p.field.Browse()
+}
+
+// Cursor implements desktop.Cursorable.
+func (p *filePickerField) Cursor() desktop.Cursor {
+	return desktop.DefaultCursor
+}
+
+// MouseIn implements desktop.Hoverable.
+func (p *filePickerField) MouseIn(*desktop.MouseEvent) {
+	p.hovered = true
+	p.field.Refresh()
+}
+
+// MouseMoved implements desktop.Hoverable.
+func (p *filePickerField) MouseMoved(*desktop.MouseEvent) {}
+
+// MouseOut implements desktop.Hoverable.
+func (p *filePickerField) MouseOut() {
+	p.hovered = false
+	p.field.Refresh()
+}
+
+// Tapped implements fyne.Tappable.
+func (p *filePickerField) Tapped(*fyne.PointEvent) {
+	p.browse()
+}
+
+// Disable implements fyne.Disableable.
+func (p *filePickerField) Disable() {
+	p.field.Disable()
+}
+
+// Enable implements fyne.Disableable.
+func (p *filePickerField) Enable() {
+	p.field.Enable()
+}
+
+// Disabled implements fyne.Disableable.
+func (p *filePickerField) Disabled() bool {
+	return p.field.Disabled()
+}
+
+// FocusGained implements fyne.Focusable.
+func (p *filePickerField) FocusGained() {
+	p.focused = true
+	p.field.focusChanged(true)
+}
+
+// FocusLost implements fyne.Focusable.
+func (p *filePickerField) FocusLost() {
+	p.focused = false
+	p.field.focusChanged(false)
+}
+
+// TypedRune implements fyne.Focusable.
+func (p *filePickerField) TypedRune(r rune) {
+	if r == ' ' {
+		p.field.Browse()
+	}
+}
+
+// TypedKey implements fyne.Focusable.
+func (p *filePickerField) TypedKey(key *fyne.KeyEvent) {
+	if p.typedNavigationKey(key.Name) {
+		return
+	}
+	if key.Name == fyne.KeyBackspace || key.Name == fyne.KeyDelete {
+		p.field.Clear()
+	}
+}
+
+func (p *filePickerField) CreateRenderer() fyne.WidgetRenderer {
+	p.ExtendBaseWidget(p)
+	r := &filePickerFieldRenderer{
+		line:   canvas.NewRectangle(theme.ShadowColor()),
+		text:   canvas.NewText("", theme.ForegroundColor()),
+		widget: p,
+	}
+	r.Refresh()
+	return r
+}
+
+type filePickerFieldRenderer struct {
+	line    *canvas.Rectangle
+	text    *canvas.Text
+	widget  *filePickerField
+	objects []fyne.CanvasObject
+}
+
+func (r *filePickerFieldRenderer) Destroy() {}
+
+func (r *filePickerFieldRenderer) Layout(size fyne.Size) {
+	pad := theme.Padding()
+	r.line.Move(fyne.NewPos(0, size.Height-theme.InputBorderSize()))
+	r.line.Resize(fyne.NewSize(size.Width, theme.InputBorderSize()))
+
+	trailing := r.trailing()
+	trailingWidth := adornmentsWidth(trailing)
+	layoutAdornments(trailing, size.Width-trailingWidth-pad, size.Height)
+
+	x := pad * 2
+	if thumb := r.widget.thumbnail; thumb != nil {
+		thumbSize := thumbnailSize()
+		thumb.Move(fyne.NewPos(x, (size.Height-thumbSize)/2))
+		thumb.Resize(fyne.NewSize(thumbSize, thumbSize))
+		x += thumbSize + pad*2
+	}
+	textMin := r.text.MinSize()
+	r.text.Move(fyne.NewPos(x, (size.Height-textMin.Height)/2))
+	r.text.Resize(fyne.NewSize(fyne.Max(0, size.Width-x-trailingWidth-pad), textMin.Height))
+}
+
+func (r *filePickerFieldRenderer) MinSize() fyne.Size {
+	pad := theme.Padding()
+	textHeight := fyne.MeasureText("M", theme.TextSize(), fyne.TextStyle{}).Height
+	min := fyne.NewSize(pad*3+adornmentsWidth(r.trailing()), textHeight+pad*4)
+	if r.widget.field.isImage {
+		min.Height = fyne.Max(min.Height, thumbnailSize()+pad*2)
+	}
+	return min
+}
+
+func (r *filePickerFieldRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+// trailing returns the buttons shown at the end of the field.
+func (r *filePickerFieldRenderer) trailing() []fyne.CanvasObject {
+	if r.widget.Disabled() {
+		return nil
+	}
+	if r.widget.field.uri != nil {
+		return []fyne.CanvasObject{r.widget.clearButton, r.widget.browseButton}
+	}
+	return []fyne.CanvasObject{r.widget.browseButton}
+}
+
+func (r *filePickerFieldRenderer) Refresh() {
+	p := r.widget
+	f := p.field
+	r.line.FillColor = theme.ShadowColor()
+	if p.focused && !p.Disabled() {
+		r.line.FillColor = theme.PrimaryColor()
+	} else if f.isErrorVisible(false) {
+		r.line.FillColor = theme.ErrorColor()
+	}
+
+	r.text.TextSize = theme.TextSize()
+	switch {
+	case f.uri != nil:
+		r.text.Text = f.uri.Name()
+		r.text.Color = theme.ForegroundColor()
+		if p.Disabled() {
+			r.text.Color = theme.DisabledColor()
+		}
+	case p.focused:
+		r.text.Text = f.Placeholder
+		r.text.Color = theme.PlaceHolderColor()
+	default:
+		r.text.Text = ""
+	}
+
+	r.objects = []fyne.CanvasObject{r.line, r.text}
+	if p.thumbnail != nil {
+		r.objects = append(r.objects, p.thumbnail)
+	}
+	r.objects = append(r.objects, r.trailing()...)
+	r.line.Refresh()
+	r.text.Refresh()
+	r.Layout(p.Size())
+	canvas.Refresh(p)
+}
+
+// ===============================================================
+// Private helpers
+// ===============================================================
+
+func thumbnailSize() float32 {
+	return theme.IconInlineSize() * 3
+}
+
+// windowForObject returns the window that shows the object.
+func windowForObject(o fyne.CanvasObject) fyne.Window {
+	c := fyne.CurrentApp().Driver().CanvasForObject(o)
+	if c == nil {
+		return nil
+	}
+	for _, w := range fyne.CurrentApp().Driver().AllWindows() {
+		if w.Canvas() == c {
+			return w
+		}
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package swid
+
+import (
+	"image"
+	"image/png"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/storage"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFileFormField(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	dir, err := ioutil.TempDir("", "swid")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	pdf := filepath.Join(dir, "report.pdf")
+	require.NoError(t, ioutil.WriteFile(pdf, make([]byte, 2048), 0644))
+	txt := filepath.Join(dir, "notes.txt")
+	require.NoError(t, ioutil.WriteFile(txt, []byte("notes"), 0644))
+
+	f := NewFileFormField("Attachment", nil)
+	f.Extensions = []string{".pdf"}
+	f.MaxSize = 1024
+	var saved fyne.URI
+	f.OnSaved = func(uri fyne.URI) { saved = uri }
+
+	form := NewForm(1, f)
+	w := test.NewWindow(form)
+	w.Resize(fyne.NewSize(300, 200))
+	defer w.Close()
+
+	assert.Nil(t, f.URI())
+	assert.NoError(t, f.ValidationError())
+
+	f.choose(storage.NewFileURI(txt))
+	assert.EqualError(t, f.ValidationError(), "File type not allowed")
+	assert.False(t, form.IsValid())
+
+	f.choose(storage.NewFileURI(pdf))
+	assert.EqualError(t, f.ValidationError(), "File must not be larger than 1.0 KB")
+
+	f.MaxSize = 4096
+	assert.NoError(t, f.Validate())
+	assert.True(t, form.IsValid())
+	f.Save()
+	assert.Equal(t, "report.pdf", saved.Name())
+
+	test.Tap(f.field.clearButton)
+	assert.Nil(t, f.URI())
+
+	test.Tap(f.field)
+	assert.NotNil(t, w.Canvas().Overlays().Top())
+	w.Canvas().Overlays().Top().Hide()
+
+	f.SetURI(storage.NewFileURI(pdf))
+	v, _ := f.draftValue()
+	f.Reset()
+	assert.Nil(t, f.URI())
+	f.restoreDraftValue(v)
+	assert.Equal(t, "report.pdf", f.URI().Name())
+}
+
+func TestImageFormField(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	dir, err := ioutil.TempDir("", "swid")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "avatar.png")
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	require.NoError(t, png.Encode(file, image.NewRGBA(image.Rect(0, 0, 4, 4))))
+	file.Close()
+
+	f := NewImageFormField("Avatar", storage.NewFileURI(path))
+	w := test.NewWindow(f)
+	w.Resize(fyne.NewSize(300, 200))
+	defer w.Close()
+
+	assert.NotNil(t, f.field.thumbnail)
+	assert.NoError(t, f.ValidationError())
+	assert.GreaterOrEqual(t, f.field.MinSize().Height, thumbnailSize())
+
+	f.field.TypedKey(&fyne.KeyEvent{Name: fyne.KeyDelete})
+	assert.Nil(t, f.URI())
+	assert.Nil(t, f.field.thumbnail)
+
+	f.choose(storage.NewFileURI(filepath.Join(dir, "doc.pdf")))
+	assert.Error(t, f.ValidationError())
+}
+
+func TestFileFormField_MaxSizeReadOnce(t *testing.T) {
+	dir, err := ioutil.TempDir("", "swid")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "report.pdf")
+	require.NoError(t, ioutil.WriteFile(path, make([]byte, 512), 0644))
+
+	f := NewFileFormField("Attachment", nil)
+	f.MaxSize = 1024
+	w := test.NewWindow(f)
+	defer w.Close()
+
+	f.choose(storage.NewFileURI(path))
+	assert.NoError(t, f.Validate())
+
+	// the size is recorded when the file is chosen, not on every validation.
+	require.NoError(t, ioutil.WriteFile(path, make([]byte, 2048), 0644))
+	assert.NoError(t, f.Validate())
+
+	f.choose(storage.NewFileURI(path))
+	assert.EqualError(t, f.Validate(), "File must not be larger than 1.0 KB")
+}
@@ -2,22 +2,62 @@ package swid
 
 import (
 	"fyne.io/fyne/v2"
-	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 )
 
+// Form should implement FormField, so it can be nested inside another form.
+var _ FormField = (*Form)(nil)
+
 // Form defines form widget.
 type Form struct {
 	widget.BaseWidget
 	OnChanged           func()
 	OnValidationChanged func(valid bool)
+	// MarkDirtyOnSubmit keeps the submit buttons enabled while the form is
+	// invalid. Tapping one of them marks all the fields as dirty, so their
+	// errors are shown, and focuses the first invalid field instead of
+	// calling the submit callback.
+	MarkDirtyOnSubmit bool
+	// ValidationTrigger defines when the fields that use the default
+	// trigger are validated.
+	ValidationTrigger ValidationTrigger
+	// DisableKeyboardNavigation disables the Tab and Enter key handling of
+	// the form fields (see SetFocusOrder).
+	DisableKeyboardNavigation bool
+	// Title is shown above the fields with a separator (see NewFormSection).
+	Title string
+	// LabelPosition defines where the labels of the fields are shown. The
+	// nested forms use the labels beside the fields if their parent does.
+	LabelPosition LabelPosition
+	// CollapseWidth makes the form show its fields in a single column when
+	// its width is smaller than this value.
+	CollapseWidth float32
+	// RescanOnRefresh makes a custom form extract again its fields from
+	// the container every time Refresh is called.
+	RescanOnRefresh bool
 
 	container     *fyne.Container
 	cols          int
 	fields        []FormField
 	isValid       bool
 	submitButtons []*widget.Button
+
+	parent   *Form
+	rules    []*fieldRule
+	inactive map[FormField]bool
+	draft    *formDraft
+	history  *formHistory
+
+	focusOrder []FormField
+	spans      map[FormField]int
+
+	// listeners are used by composite fields and widgets bound to this
+	// form to be notified when a field of this form changes.
+	listeners []func()
 }
 
 // NewForm creates a new form widget.
@@ -58,16 +98,159 @@ func (f *Form) Reset() {
 }
 
 // Save triggers onSaved callback of all FormFields.
-//
+// If the draft autosave is enabled, the stored draft is cleared.
 func (f *Form) Save() {
 	for _, field := range f.fields {
+		if f.inactive[field] {
+			continue
+		}
 		field.Save()
 	}
+	f.ClearDraft()
+}
+
+// ValidationError returns the first validation error found in the form fields.
+// It implements FormField, so a form can be nested inside another form.
+func (f *Form) ValidationError() error {
+	return f.firstError()
+}
+
+// Validate validates all the form fields and returns the first error found.
+func (f *Form) Validate() error {
+	var firstErr error
+	for _, field := range f.fields {
+		if err := field.Validate(); err != nil && firstErr == nil && !f.inactive[field] {
+			firstErr = err
+		}
+	}
+	f.validate()
+	return firstErr
+}
+
+// Errors returns the current validation errors of the form fields,
+// including the ones of nested forms.
+func (f *Form) Errors() []FieldError {
+	var errs []FieldError
+	for _, field := range f.fields {
+		if f.inactive[field] {
+			continue
+		}
+		if nested, ok := field.(*Form); ok {
+			errs = append(errs, nested.Errors()...)
+			continue
+		}
+		if err := field.ValidationError(); err != nil {
+			label := ""
+			if lf, ok := field.(labeledField); ok {
+				label = lf.fieldLabel()
+			}
+			errs = append(errs, FieldError{Field: field, Label: label, Err: err})
+		}
+	}
+	return errs
+}
+
+// FocusFirstInvalid focuses the first invalid field, scrolling to it
+// if it is inside a scroll container. It returns false if there is no
+// invalid field.
+func (f *Form) FocusFirstInvalid() bool {
+	errs := f.Errors()
+	if len(errs) == 0 {
+		return false
+	}
+	focusField(errs[0].Field)
+	return true
+}
+
+// MarkDirty marks all the fields as dirty, so their validation errors
+// are shown even if the user has not edited them yet.
+func (f *Form) MarkDirty() {
+	f.Validate()
+	for _, field := range f.fields {
+		if df, ok := field.(dirtyField); ok {
+			df.markDirty()
+		}
+	}
+}
+
+func (f *Form) markDirty() {
+	f.MarkDirty()
+}
+
+// AddField appends a field to the form.
+// For custom forms, the field is only attached to the form, so the caller
+// must place it inside the container.
+func (f *Form) AddField(field FormField) {
+	f.InsertField(len(f.fields), field)
+}
+
+// InsertField inserts a field in the form at the specified index.
+// If the index is out of range, the field is appended at the end.
+func (f *Form) InsertField(index int, field FormField) {
+	if index < 0 || index > len(f.fields) {
+		index = len(f.fields)
+	}
+	fields := make([]FormField, 0, len(f.fields)+1)
+	fields = append(fields, f.fields[:index]...)
+	fields = append(fields, field)
+	fields = append(fields, f.fields[index:]...)
+	f.setFields(fields)
+	f.BaseWidget.Refresh()
+}
+
+// RemoveField removes a field from the form. It returns false if the field
+// does not belong to the form.
+// For custom forms, the field is only detached from the form, so the caller
+// must remove it from the container.
+func (f *Form) RemoveField(field FormField) bool {
+	i := indexOfField(f.fields, field)
+	if i < 0 {
+		return false
+	}
+	fields := make([]FormField, 0, len(f.fields)-1)
+	fields = append(fields, f.fields[:i]...)
+	fields = append(fields, f.fields[i+1:]...)
+	f.setFields(fields)
+	f.BaseWidget.Refresh()
+	return true
+}
+
+// Refresh implements fyne.Widget.
+func (f *Form) Refresh() {
+	if f.container != nil && f.RescanOnRefresh {
+		f.setFields(fieldsFromContent(make([]FormField, 0, len(f.fields)), f.container))
+	}
+	f.BaseWidget.Refresh()
+}
+
+// ShowWhen makes the field visible only when the condition is true.
+// The condition is evaluated every time a field of the form changes.
+// While the field is hidden, it is excluded from validation and Save.
+func (f *Form) ShowWhen(field FormField, condition func() bool) {
+	f.rules = append(f.rules, &fieldRule{field: field, condition: condition})
+	f.validate()
+}
+
+// EnableWhen makes the field enabled only when the condition is true.
+// The condition is evaluated every time a field of the form changes.
+// While the field is disabled, it is excluded from validation and Save.
+func (f *Form) EnableWhen(field FormField, condition func() bool) {
+	f.rules = append(f.rules, &fieldRule{field: field, condition: condition, enablement: true})
+	f.validate()
 }
 
 // CreateSubmitButton creates a new form submit button.
 func (f *Form) CreateSubmitButton(text string, onTapped func()) *widget.Button {
-	btn := widget.NewButton(text, onTapped)
+	btn := widget.NewButton(text, func() {
+		if (f.MarkDirtyOnSubmit || f.hasPendingValidation()) && f.Validate() != nil {
+			f.MarkDirty()
+			f.FocusFirstInvalid()
+			return
+		}
+		if onTapped != nil {
+			onTapped()
+		}
+	})
 	btn.Importance = widget.HighImportance
 	f.submitButtons = append(f.submitButtons, btn)
 	return btn
@@ -80,7 +263,7 @@ func (f *Form) CreateResetButton(text string) *widget.Button {
 
 // updates submit button state if there is one.
 func (f *Form) updateSubmitButtonState() {
-	isValid := f.isValid
+	isValid := f.isValid || f.MarkDirtyOnSubmit
 	for _, btn := range f.submitButtons {
 		if isValid {
 			btn.Enable()
@@ -93,8 +276,15 @@ func (f *Form) updateSubmitButtonState() {
 // Validate validates the form. If it is invalid, it will return
 // the first error found.
 func (f *Form) validate() {
+	if f.applyRules() {
+		f.BaseWidget.Refresh()
+	}
 	isValid := true
 	for _, field := range f.fields {
+		// the fields with a pending validation are validated on submit.
+		if f.inactive[field] || isValidationPending(field) {
+			continue
+		}
 		// use only validationError because the validation is done
 		// automatically by the form fields itself.
 		if err := field.ValidationError(); err != nil && isValid {
@@ -112,12 +302,137 @@ func (f *Form) validate() {
 	}
 }
 
+// applyRules evaluates the visibility and enablement rules, updating
+// the fields state and the inactive fields. It returns true if the
+// visibility of any field has changed, so the form needs to be reflowed.
+func (f *Form) applyRules() bool {
+	if len(f.rules) == 0 {
+		return false
+	}
+	inactive := make(map[FormField]bool)
+	reflow := false
+	for _, rule := range f.rules {
+		active := rule.condition()
+		if !active {
+			inactive[rule.field] = true
+		}
+		if rule.enablement {
+			if d, ok := rule.field.(fyne.Disableable); ok && d.Disabled() == active {
+				if active {
+					d.Enable()
+				} else {
+					d.Disable()
+				}
+			}
+			continue
+		}
+		if rule.field.Visible() != active {
+			if active {
+				rule.field.Show()
+			} else {
+				rule.field.Hide()
+			}
+			reflow = true
+		}
+	}
+	f.inactive = inactive
+	return reflow
+}
+
+// hasPendingValidation returns true if a field (including the ones of
+// nested forms and list items) has a change that has not been validated
+// because of its validation trigger.
+func (f *Form) hasPendingValidation() bool {
+	for _, field := range f.fields {
+		if f.inactive[field] {
+			continue
+		}
+		switch ff := field.(type) {
+		case *Form:
+			if ff.hasPendingValidation() {
+				return true
+			}
+		case *ListFormField:
+			for _, item := range ff.items {
+				if item.form.hasPendingValidation() {
+					return true
+				}
+			}
+		default:
+			if isValidationPending(field) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// disabledByRule returns true if an EnableWhen rule disables the field.
+func (f *Form) disabledByRule(field FormField) bool {
+	for _, rule := range f.rules {
+		if rule.enablement && rule.field == field && !rule.condition() {
+			return true
+		}
+	}
+	return false
+}
+
+// firstError returns the first validation error found in the form fields.
+// The fields with a pending validation are skipped.
+func (f *Form) firstError() error {
+	for _, field := range f.fields {
+		if f.inactive[field] || isValidationPending(field) {
+			continue
+		}
+		if err := field.ValidationError(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setFields replaces the form fields, attaching the new ones and detaching
+// the ones that are not present anymore.
+func (f *Form) setFields(fields []FormField) {
+	for _, field := range f.fields {
+		if indexOfField(fields, field) < 0 {
+			field.setParentForm(nil)
+		}
+	}
+	for _, field := range fields {
+		if indexOfField(f.fields, field) < 0 {
+			field.setParentForm(f)
+			field.Validate()
+		}
+	}
+	f.fields = fields
+	f.validate()
+}
+
 // fieldDidChange must be called from a form field.
 func (f *Form) fieldDidChange() {
 	if f.OnChanged != nil {
 		f.OnChanged()
 	}
 	f.validate()
+	f.recordHistory()
+	f.scheduleDraft()
+	for _, listener := range f.listeners {
+		listener()
+	}
+	f.didChange()
+}
+
+func (f *Form) setParentForm(parent *Form) {
+	f.parent = parent
+}
+
+// didChange notifies the parent form, if this form is nested.
+func (f *Form) didChange() {
+	if f.parent == nil {
+		return
+	}
+	f.parent.fieldDidChange()
 }
 
 // ===============================================================
@@ -129,10 +444,11 @@ func (f *Form) fieldDidChange() {
 func (f *Form) CreateRenderer() fyne.WidgetRenderer {
 	f.ExtendBaseWidget(f)
 	objects := make([]fyne.CanvasObject, len(f.fields))
+	f.applyRules()
 	f.isValid = true
 	for i, field := range f.fields {
 		field.setParentForm(f)
-		if err := field.Validate(); err != nil && f.isValid {
+		if err := field.Validate(); err != nil && f.isValid && !f.inactive[field] {
 			f.isValid = false
 		}
 		objects[i] = field
@@ -142,34 +458,91 @@ func (f *Form) CreateRenderer() fyne.WidgetRenderer {
 		f.OnValidationChanged(f.isValid)
 	}
 	if f.container == nil {
-		return &formRenderer{
-			layout:  layout.NewGridLayoutWithColumns(f.cols),
-			objects: objects,
+		title := widget.NewLabel(f.Title)
+		title.TextStyle.Bold = true
+		r := &formRenderer{
+			layout:    newFormLayout(f),
+			title:     title,
+			separator: widget.NewSeparator(),
+			widget:    f,
 		}
+		r.updateObjects()
+		return r
 	}
 	return &containerFormRenderer{widget: f}
 }
 
+// FieldError defines a validation error of a form field.
+type FieldError struct {
+	Field FormField
+	Label string
+	Err   error
+}
+
+// fieldRule defines a visibility or an enablement rule of a form field.
+type fieldRule struct {
+	field      FormField
+	condition  func() bool
+	enablement bool
+}
+
 type formRenderer struct {
-	layout  fyne.Layout
-	objects []fyne.CanvasObject
+	layout    *formLayout
+	title     *widget.Label
+	separator *widget.Separator
+	objects   []fyne.CanvasObject
+	widget    *Form
 }
 
 func (r *formRenderer) Destroy() {}
 
 func (r *formRenderer) Layout(size fyne.Size) {
-	r.layout.Layout(r.objects, size)
+	top := float32(0)
+	if r.widget.Title != "" {
+		titleHeight := r.title.MinSize().Height
+		r.title.Move(fyne.NewPos(0, 0))
+		r.title.Resize(fyne.NewSize(size.Width, titleHeight))
+		r.separator.Move(fyne.NewPos(0, titleHeight))
+		r.separator.Resize(fyne.NewSize(size.Width, theme.SeparatorThicknessSize()))
+		top = titleHeight + theme.SeparatorThicknessSize() + theme.Padding()
+	}
+	r.layout.layout(fyne.NewPos(0, top), fyne.NewSize(size.Width, size.Height-top))
 }
 
 func (r *formRenderer) MinSize() fyne.Size {
-	return r.layout.MinSize(r.objects)
+	min := r.layout.minSize()
+	if r.widget.Title != "" {
+		titleMin := r.title.MinSize()
+		min.Width = fyne.Max(min.Width, titleMin.Width)
+		min.Height += titleMin.Height + theme.SeparatorThicknessSize() + theme.Padding()
+	}
+	return min
 }
 
 func (r *formRenderer) Objects() []fyne.CanvasObject {
 	return r.objects
 }
 
-func (r *formRenderer) Refresh() {}
+func (r *formRenderer) Refresh() {
+	r.title.SetText(r.widget.Title)
+	r.updateObjects()
+	// always layout, so hidden fields can be reflowed.
+	r.Layout(r.widget.Size())
+	canvas.Refresh(r.widget)
+}
+
+func (r *formRenderer) updateObjects() {
+	labels := r.layout.objects()
+	objects := make([]fyne.CanvasObject, 0, len(r.widget.fields)+len(labels)+2)
+	if r.widget.Title != "" {
+		objects = append(objects, r.title, r.separator)
+	}
+	objects = append(objects, labels...)
+	for _, field := range r.widget.fields {
+		objects = append(objects, field)
+	}
+	r.objects = objects
+}
 
 type containerFormRenderer struct {
 	widget *Form
@@ -202,13 +575,14 @@ func fieldsFromContent(fields []FormField, content fyne.CanvasObject) []FormFiel
 		return fields
 	}
 	switch o := content.(type) {
+	case FormField:
+		// do not look inside form fields, nested fields (like the ones of a
+		// nested form) are handled by the form field itself.
+		fields = append(fields, o)
 	case fyne.Widget:
 		for _, co := range test.WidgetRenderer(o).Objects() {
 			fields = fieldsFromContent(fields, co)
 		}
-		if ff, ok := o.(FormField); ok {
-			fields = append(fields, ff)
-		}
 	case *fyne.Container:
 		for _, co := range o.Objects {
 			fields = fieldsFromContent(fields, co)
@@ -216,3 +590,65 @@ func fieldsFromContent(fields []FormField, content fyne.CanvasObject) []FormFiel
 	}
 	return fields
 }
+
+// scrollToObject scrolls all the scroll containers that hold the object,
+// so the object becomes visible.
+func scrollToObject(o fyne.CanvasObject) {
+	c := fyne.CurrentApp().Driver().CanvasForObject(o)
+	if c == nil || c.Content() == nil {
+		return
+	}
+	path := pathToObject(nil, c.Content(), o)
+	d := fyne.CurrentApp().Driver()
+	// scroll from the innermost container to the outermost one.
+	for i := len(path) - 1; i >= 0; i-- {
+		scroll, ok := path[i].(*container.Scroll)
+		if !ok {
+			continue
+		}
+		pos := d.AbsolutePositionForObject(o).Subtract(d.AbsolutePositionForObject(scroll.Content))
+		size := o.Size()
+		if pos.Y < scroll.Offset.Y {
+			scroll.Offset.Y = pos.Y
+		} else if pos.Y+size.Height > scroll.Offset.Y+scroll.Size().Height {
+			scroll.Offset.Y = pos.Y + size.Height - scroll.Size().Height
+		}
+		if pos.X < scroll.Offset.X {
+			scroll.Offset.X = pos.X
+		} else if pos.X+size.Width > scroll.Offset.X+scroll.Size().Width {
+			scroll.Offset.X = pos.X + size.Width - scroll.Size().Width
+		}
+		scroll.Refresh()
+	}
+}
+
+// pathToObject returns the objects from root to target (both included),
+// or nil if target is not inside root.
+func pathToObject(path []fyne.CanvasObject, root, target fyne.CanvasObject) []fyne.CanvasObject {
+	path = append(path, root)
+	if root == target {
+		return path
+	}
+	var children []fyne.CanvasObject
+	switch o := root.(type) {
+	case *fyne.Container:
+		children = o.Objects
+	case fyne.Widget:
+		children = test.WidgetRenderer(o).Objects()
+	}
+	for _, child := range children {
+		if p := pathToObject(path, child, target); p != nil {
+			return p
+		}
+	}
+	return nil
+}
+
+func indexOfField(fields []FormField, field FormField) int {
+	for i, ff := range fields {
+		if ff == field {
+			return i
+		}
+	}
+	return -1
+}
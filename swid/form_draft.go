@@ -0,0 +1,210 @@
+package swid
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2"
+)
+
+// DraftStore defines a storage for form drafts.
+type DraftStore interface {
+	// LoadDraft returns the stored draft values. It must return false
+	// if there is no draft for the key.
+	LoadDraft(key string) (values map[string]string, ok bool)
+	// SaveDraft stores the draft values.
+	SaveDraft(key string, values map[string]string)
+	// ClearDraft removes the stored draft.
+	ClearDraft(key string)
+}
+
+// NewPreferencesDraftStore creates a draft store that keeps the drafts
+// in the fyne preferences (encoded as JSON strings).
+func NewPreferencesDraftStore(prefs fyne.Preferences) DraftStore {
+	return &preferencesDraftStore{prefs: prefs}
+}
+
+type preferencesDraftStore struct {
+	prefs fyne.Preferences
+}
+
+func (s *preferencesDraftStore) LoadDraft(key string) (map[string]string, bool) {
+	data := s.prefs.String(key)
+	if data == "" {
+		return nil, false
+	}
+	values := map[string]string{}
+	if err := json.Unmarshal([]byte(data), &values); err != nil {
+		return nil, false
+	}
+	return values, true
+}
+
+func (s *preferencesDraftStore) SaveDraft(key string, values map[string]string) {
+	data, err := json.Marshal(values)
+	if err != nil {
+		return
+	}
+	s.prefs.SetString(key, string(data))
+}
+
+func (s *preferencesDraftStore) ClearDraft(key string) {
+	s.prefs.RemoveValue(key)
+}
+
+// draftField is implemented by the form fields whose value can be stored
+// in a draft.
+type draftField interface {
+	// draftValue returns the field value. It returns false if the value
+	// must not be stored (like passwords).
+	draftValue() (string, bool)
+	restoreDraftValue(v string)
+}
+
+// formDraft keeps the draft autosave state of a form.
+type formDraft struct {
+	key   string
+	store DraftStore
+	delay time.Duration
+
+	lock      sync.Mutex
+	timer     *time.Timer
+	restoring bool
+	// pending are the values to store when the timer fires. They are read
+	// from the fields when they change, so the timer does not access the
+	// widgets.
+	pending map[string]string
+}
+
+// ===============================================================
+// Form methods
+// ===============================================================
+
+// EnableDraft enables the draft autosave. Every time a field changes,
+// the form values (excluding password fields) are stored with the key
+// after the delay has elapsed without new changes.
+// Fields are identified by their position in the form.
+func (f *Form) EnableDraft(key string, store DraftStore, delay time.Duration) {
+	f.draft = &formDraft{key: key, store: store, delay: delay}
+}
+
+// HasDraft returns true if there is a stored draft for this form.
+func (f *Form) HasDraft() bool {
+	if f.draft == nil {
+		return false
+	}
+	_, ok := f.draft.store.LoadDraft(f.draft.key)
+	return ok
+}
+
+// RestoreDraft restores the values of the stored draft. It returns false
+// if there is no draft.
+func (f *Form) RestoreDraft() bool {
+	if f.draft == nil {
+		return false
+	}
+	values, ok := f.draft.store.LoadDraft(f.draft.key)
+	if !ok {
+		return false
+	}
+	f.draft.lock.Lock()
+	f.draft.restoring = true
+	f.draft.lock.Unlock()
+
+	f.restoreDraftValues("", values)
+
+	f.draft.lock.Lock()
+	f.draft.restoring = false
+	f.draft.lock.Unlock()
+	f.validate()
+	return true
+}
+
+// ClearDraft removes the stored draft and cancels any pending autosave.
+func (f *Form) ClearDraft() {
+	if f.draft == nil {
+		return
+	}
+	f.draft.lock.Lock()
+	if f.draft.timer != nil {
+		f.draft.timer.Stop()
+		f.draft.timer = nil
+	}
+	f.draft.pending = nil
+	f.draft.lock.Unlock()
+	f.draft.store.ClearDraft(f.draft.key)
+}
+
+// scheduleDraft takes the current values and schedules the draft
+// autosave.
+func (f *Form) scheduleDraft() {
+	d := f.draft
+	if d == nil {
+		return
+	}
+	d.lock.Lock()
+	restoring := d.restoring
+	d.lock.Unlock()
+	if restoring {
+		return
+	}
+	values := map[string]string{}
+	f.draftValues("", values)
+
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if d.timer != nil {
+		d.timer.Stop()
+	}
+	d.pending = values
+	if d.delay <= 0 {
+		d.timer = nil
+		d.savePending()
+		return
+	}
+	d.timer = time.AfterFunc(d.delay, func() {
+		d.lock.Lock()
+		defer d.lock.Unlock()
+		d.savePending()
+	})
+}
+
+// savePending stores the pending values. It must be called with the lock
+// held.
+func (d *formDraft) savePending() {
+	if d.pending == nil {
+		return
+	}
+	d.store.SaveDraft(d.key, d.pending)
+	d.pending = nil
+}
+
+func (f *Form) draftValues(prefix string, values map[string]string) {
+	for i, field := range f.fields {
+		key := prefix + strconv.Itoa(i)
+		switch ff := field.(type) {
+		case *Form:
+			ff.draftValues(key+".", values)
+		case draftField:
+			if v, ok := ff.draftValue(); ok {
+				values[key] = v
+			}
+		}
+	}
+}
+
+func (f *Form) restoreDraftValues(prefix string, values map[string]string) {
+	for i, field := range f.fields {
+		key := prefix + strconv.Itoa(i)
+		switch ff := field.(type) {
+		case *Form:
+			ff.restoreDraftValues(key+".", values)
+		case draftField:
+			if v, ok := values[key]; ok {
+				ff.restoreDraftValue(v)
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package swid
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForm_Draft(t *testing.T) {
+	app := test.NewApp()
+	defer test.NewApp()
+	store := NewPreferencesDraftStore(app.Preferences())
+
+	newForm := func() (*Form, *TextFormField, *TextFormField, *SelectFormField, *TextFormField) {
+		name := NewTextFormField("Name", "")
+		password := NewPasswordTextFormField("Password", "")
+		car := NewSelectFormField("Car", "", []string{"Audi", "Toyota"})
+		street := NewTextFormField("Street", "")
+		f := NewForm(1, name, password, car, NewForm(1, street))
+		f.EnableDraft("signup", store, 0)
+		return f, name, password, car, street
+	}
+
+	f, name, password, car, street := newForm()
+	w := test.NewWindow(f)
+	defer w.Close()
+
+	assert.False(t, f.HasDraft())
+	name.SetText("Peter")
+	password.SetText("secret")
+	car.selectField.SetSelected("Toyota")
+	street.SetText("Av. Big one")
+	assert.True(t, f.HasDraft())
+
+	f2, name2, password2, car2, street2 := newForm()
+	assert.True(t, f2.RestoreDraft())
+	assert.Equal(t, "Peter", name2.Text())
+	assert.Equal(t, "", password2.Text())
+	assert.Equal(t, "Toyota", car2.Selected())
+	assert.Equal(t, "Av. Big one", street2.Text())
+
+	f2.Save()
+	assert.False(t, f2.HasDraft())
+	assert.False(t, f.RestoreDraft())
+}
+
+func TestForm_DraftDebounce(t *testing.T) {
+	app := test.NewApp()
+	defer test.NewApp()
+	store := NewPreferencesDraftStore(app.Preferences())
+
+	name := NewTextFormField("Name", "")
+	f := NewForm(1, name)
+	f.EnableDraft("debounce", store, 50*time.Millisecond)
+	w := test.NewWindow(f)
+	defer w.Close()
+
+	name.SetText("P")
+	name.SetText("Pe")
+	assert.False(t, f.HasDraft())
+	time.Sleep(150 * time.Millisecond)
+	values, ok := store.LoadDraft("debounce")
+	assert.True(t, ok)
+	assert.Equal(t, map[string]string{"0": "Pe"}, values)
+
+	name.SetText("Pet")
+	f.ClearDraft()
+	time.Sleep(100 * time.Millisecond)
+	assert.False(t, f.HasDraft())
+}
+
+func TestForm_DraftList(t *testing.T) {
+	app := test.NewApp()
+	defer test.NewApp()
+	store := NewPreferencesDraftStore(app.Preferences())
+
+	newForm := func() (*Form, *ListFormField) {
+		phones := NewListFormField("Phones", phoneTemplate, []interface{}{"111"})
+		f := NewForm(1, phones)
+		f.EnableDraft("phones", store, 0)
+		return f, phones
+	}
+
+	f, phones := newForm()
+	w := test.NewWindow(f)
+	defer w.Close()
+
+	phones.AddItem("222")
+	phones.items[0].form.fields[0].(*TextFormField).SetText("000")
+
+	f2, phones2 := newForm()
+	assert.True(t, f2.RestoreDraft())
+	assert.Equal(t, []interface{}{"000", "222"}, phones2.Values())
+}
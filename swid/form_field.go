@@ -22,16 +22,116 @@ type FormField interface {
 	didChange()
 }
 
+// focusableField is implemented by the form fields that have an internal
+// widget that can be focused.
+type focusableField interface {
+	focusTarget() fyne.Focusable
+}
+
+// labeledField is implemented by the form fields that have a label.
+type labeledField interface {
+	fieldLabel() string
+}
+
+// counterField is implemented by the form fields that can show a counter
+// at the end of the hint line.
+type counterField interface {
+	// counter returns the counter text and color. It returns false if the
+	// counter must be hidden.
+	counter() (text string, c color.Color, visible bool)
+}
+
+// adornedField is implemented by the form fields that show content before
+// the input, so the label is placed after it while it is not stacked.
+type adornedField interface {
+	leadingInset() float32
+}
+
+// dirtyField is implemented by the form fields that can be marked as
+// dirty to show their validation errors.
+type dirtyField interface {
+	markDirty()
+}
+
+// pendingField is implemented by the form fields whose validation can be
+// deferred by their validation trigger.
+type pendingField interface {
+	isValidationPending() bool
+}
+
+// isValidationPending returns true if the field has a change that has not
+// been validated yet.
+func isValidationPending(field FormField) bool {
+	pf, ok := field.(pendingField)
+	return ok && pf.isValidationPending()
+}
+
+// focusField scrolls to a form field and focuses its internal widget.
+// It returns false if the field can not be focused.
+func focusField(field FormField) bool {
+	scrollToObject(field)
+	ff, ok := field.(focusableField)
+	if !ok {
+		return false
+	}
+	target := ff.focusTarget()
+	c := fyne.CurrentApp().Driver().CanvasForObject(field)
+	if c == nil {
+		return false
+	}
+	c.Focus(target)
+	return true
+}
+
+// ValidationTrigger defines when a form field runs its validator and
+// when its validation error is shown. While a change has not been
+// validated yet, the form does not count the field as invalid, and it
+// validates the field on submit.
+type ValidationTrigger int
+
+// ValidationTrigger options
+const (
+	// ValidationTriggerDefault validates on every change and shows the error
+	// when the field is dirty and not focused. A field with this trigger uses
+	// the trigger of its form.
+	ValidationTriggerDefault ValidationTrigger = iota
+	// ValidationTriggerOnChange validates on every change and shows the error
+	// as soon as the field is dirty, even if it is focused.
+	ValidationTriggerOnChange
+	// ValidationTriggerOnBlur validates and shows the error only when the
+	// field loses the focus.
+	ValidationTriggerOnBlur
+	// ValidationTriggerOnSubmit validates and shows the error only when
+	// the form is submitted (see Form.MarkDirty).
+	ValidationTriggerOnSubmit
+	// ValidationTriggerAfterBlur validates on blur until the field loses the
+	// focus for the first time, then it validates and shows the error on
+	// every change.
+	ValidationTriggerAfterBlur
+)
+
 // BaseFormField defines a base form field.
 type BaseFormField struct {
 	widget.DisableableWidget
 	Label string
 	Hint  string
+	// ValidationTrigger defines when the field is validated. If it is
+	// ValidationTriggerDefault, the trigger of the parent form is used.
+	ValidationTrigger ValidationTrigger
+	// EnterAction defines what happens when the Enter key is typed in the
+	// field (only for single-line fields).
+	EnterAction EnterAction
 
 	labelAnim       *labelAnimation
 	dirty           bool
-	validationError error
-	form            *Form
+	blurred         bool
+	submitted       bool
+	forceValidation bool
+	// validationPending is set when the value changed but the validation
+	// trigger did not allow the validator to run.
+	validationPending bool
+	validationError   error
+	form              *Form
 
 	impl fyne.Widget
 }
@@ -56,6 +156,118 @@ func (b *BaseFormField) didChange() {
 	b.form.fieldDidChange()
 }
 
+func (b *BaseFormField) fieldLabel() string {
+	return b.Label
+}
+
+func (b *BaseFormField) markDirty() {
+	b.dirty = true
+	b.submitted = true
+	if b.impl != nil {
+		b.impl.Refresh()
+	}
+}
+
+func (b *BaseFormField) capturesTab() bool {
+	return b.form != nil && !b.form.root().DisableKeyboardNavigation
+}
+
+func (b *BaseFormField) typedKey(key fyne.KeyName, shift bool) bool {
+	field, ok := b.impl.(FormField)
+	if !ok || !b.capturesTab() {
+		return false
+	}
+	return b.form.navigate(field, key, shift, b.EnterAction)
+}
+
+// resetDirty restores the field to its pristine state.
+func (b *BaseFormField) resetDirty() {
+	b.dirty = false
+	b.blurred = false
+	b.submitted = false
+	b.validationPending = false
+}
+
+// validationTrigger returns the trigger of the field, or the one of its
+// form if the field uses the default trigger.
+func (b *BaseFormField) validationTrigger() ValidationTrigger {
+	if b.ValidationTrigger != ValidationTriggerDefault {
+		return b.ValidationTrigger
+	}
+	for f := b.form; f != nil; f = f.parent {
+		if f.ValidationTrigger != ValidationTriggerDefault {
+			return f.ValidationTrigger
+		}
+	}
+	return ValidationTriggerDefault
+}
+
+// shouldRunValidator returns true if the validator must run when the
+// field value changes. If it must not, the validation is marked as
+// pending until the field is validated.
+func (b *BaseFormField) shouldRunValidator() bool {
+	run := true
+	if !b.forceValidation {
+		switch b.validationTrigger() {
+		case ValidationTriggerOnBlur, ValidationTriggerOnSubmit:
+			run = false
+		case ValidationTriggerAfterBlur:
+			run = b.blurred
+		}
+	}
+	b.validationPending = !run
+	return run
+}
+
+// isValidationPending returns true if the last change of the field has
+// not been validated yet.
+func (b *BaseFormField) isValidationPending() bool {
+	return b.validationPending
+}
+
+// validateOnBlur returns true if the field must be validated when
+// it loses the focus.
+func (b *BaseFormField) validateOnBlur() bool {
+	t := b.validationTrigger()
+	return t == ValidationTriggerOnBlur || t == ValidationTriggerAfterBlur
+}
+
+// forceValidate runs validate even if the validation trigger does not
+// allow it.
+func (b *BaseFormField) forceValidate(validate func() error) error {
+	b.forceValidation = true
+	defer func() { b.forceValidation = false }()
+	return validate()
+}
+
+// isErrorVisible returns true if the validation error must be shown in
+// the hint.
+func (b *BaseFormField) isErrorVisible(focused bool) bool {
+	if b.Disabled() || b.validationError == nil {
+		return false
+	}
+	switch b.validationTrigger() {
+	case ValidationTriggerOnChange:
+		return b.dirty
+	case ValidationTriggerOnBlur:
+		return !focused && (b.blurred || b.submitted)
+	case ValidationTriggerOnSubmit:
+		return b.submitted
+	case ValidationTriggerAfterBlur:
+		return b.blurred || b.submitted
+	}
+	return !focused && b.dirty
+}
+
+// typedShortcut lets the parent form handle the shortcuts typed in
+// the internal widget. It returns true if the shortcut was handled.
+func (b *BaseFormField) typedShortcut(s fyne.Shortcut) bool {
+	if b.form == nil {
+		return false
+	}
+	return b.form.typedShortcut(s)
+}
+
 // ===============================================================
 // BaseRenderer
 // ===============================================================
@@ -67,24 +279,52 @@ func (b *BaseFormField) CreateBaseRenderer(
 	isFieldFocused func() bool,
 	updateInternalField func(),
 ) fyne.WidgetRenderer {
+	r := b.newBaseRenderer(labelText, hintText, fieldWidget, isFieldEmpty, isFieldFocused, updateInternalField)
+	r.Refresh() // ensure initial state
+	return r
+}
+
+// createPristineRenderer creates a base form field renderer for the fields
+// that are never empty, which are only marked as dirty when the user changes
+// them or the form is submitted.
+func (b *BaseFormField) createPristineRenderer(
+	labelText, hintText string, fieldWidget fyne.Widget,
+	isFieldEmpty func() bool,
+	isFieldFocused func() bool,
+	updateInternalField func(),
+) fyne.WidgetRenderer {
+	r := b.newBaseRenderer(labelText, hintText, fieldWidget, isFieldEmpty, isFieldFocused, updateInternalField)
+	r.keepPristine = true
+	r.Refresh() // ensure initial state
+	return r
+}
+
+func (b *BaseFormField) newBaseRenderer(
+	labelText, hintText string, fieldWidget fyne.Widget,
+	isFieldEmpty func() bool,
+	isFieldFocused func() bool,
+	updateInternalField func(),
+) *formFieldRenderer {
 	labelBg := newLabelBackground(theme.InputBackgroundColor(), fieldWidget)
 	label := canvas.NewText(labelText, theme.PlaceHolderColor())
 	hint := canvas.NewText(hintText, theme.PlaceHolderColor())
 	hint.TextSize = hintTextSize()
-	r := &formFieldRenderer{
+	counter := canvas.NewText("", theme.PlaceHolderColor())
+	counter.TextSize = hintTextSize()
+	counter.Hide()
+	return &formFieldRenderer{
 		labelBg:             labelBg,
 		label:               label,
 		fieldWidget:         fieldWidget,
 		hint:                hint,
+		counter:             counter,
 		labelBgColor:        func() color.Color { return theme.InputBackgroundColor() },
 		isFieldEmpty:        isFieldEmpty,
 		isFieldFocused:      isFieldFocused,
 		updateInternalField: updateInternalField,
 		formField:           b,
-		objects:             []fyne.CanvasObject{labelBg, fieldWidget, label, hint},
+		objects:             []fyne.CanvasObject{labelBg, fieldWidget, label, hint, counter},
 	}
-	r.Refresh() // ensure initial state
-	return r
 }
 
 type formFieldRenderer struct {
@@ -92,11 +332,15 @@ type formFieldRenderer struct {
 	label       *canvas.Text
 	fieldWidget fyne.Widget
 	hint        *canvas.Text
+	counter     *canvas.Text
 
 	labelBgColor        func() color.Color
 	isFieldEmpty        func() bool
 	isFieldFocused      func() bool
 	updateInternalField func()
+	// keepPristine avoids marking the field as dirty when it is not empty,
+	// for the fields that are never empty (see createPristineRenderer).
+	keepPristine bool
 
 	formField *BaseFormField
 	objects   []fyne.CanvasObject
@@ -110,6 +354,15 @@ func (r *formFieldRenderer) Destroy() {
 
 func (r *formFieldRenderer) Layout(size fyne.Size) {
 	insetPad := r.fieldInsetPad()
+	if r.formField.hasSideLabel() {
+		// the label is shown by the form.
+		r.labelBg.Resize(fyne.NewSize(0, 0))
+		fieldMinHeight := r.fieldWidget.MinSize().Height
+		r.fieldWidget.Move(fyne.NewPos(0, 0))
+		r.fieldWidget.Resize(fyne.NewSize(size.Width, fieldMinHeight))
+		r.layoutHint(fieldMinHeight, size.Width)
+		return
+	}
 	stackedLabelTextSize, _ := r.stackedLabelProps()
 	stackedlabelMinHeight := fyne.MeasureText(r.label.Text, stackedLabelTextSize, r.label.TextStyle).Height
 	r.labelBg.Move(fyne.NewPos(0, 0))
@@ -124,7 +377,7 @@ func (r *formFieldRenderer) Layout(size fyne.Size) {
 			r.label.Move(fyne.NewPos(insetPad, labelPosY))
 		} else {
 			r.label.TextSize, labelPosY = r.nonStackedLabelProps()
-			r.label.Move(fyne.NewPos(insetPad, labelPosY))
+			r.label.Move(fyne.NewPos(r.nonStackedLabelPosX(), labelPosY))
 		}
 	}
 
@@ -137,15 +390,43 @@ func (r *formFieldRenderer) Layout(size fyne.Size) {
 	r.fieldWidget.Resize(fyne.NewSize(size.Width, fieldMinHeight))
 
 	ypos += fieldMinHeight
+	r.layoutHint(ypos, size.Width)
+}
+
+// layoutHint places the hint and the counter (if visible) in the same line.
+func (r *formFieldRenderer) layoutHint(ypos, width float32) {
+	insetPad := r.fieldInsetPad()
+	hintWidth := width - 2*insetPad
+	if r.counter.Visible() {
+		counterWidth := r.counter.MinSize().Width
+		r.counter.Move(fyne.NewPos(width-insetPad-counterWidth, ypos))
+		r.counter.Resize(fyne.NewSize(counterWidth, r.counter.MinSize().Height))
+		hintWidth -= counterWidth + theme.Padding()
+	}
 	r.hint.Move(fyne.NewPos(insetPad, ypos))
-	r.hint.Resize(fyne.NewSize(size.Width-2*insetPad, r.hint.MinSize().Height))
+	r.hint.Resize(fyne.NewSize(hintWidth, r.hint.MinSize().Height))
+}
+
+// hintMinSize returns the min size of the hint line.
+func (r *formFieldRenderer) hintMinSize() fyne.Size {
+	min := r.hint.MinSize()
+	if r.counter.Visible() {
+		min.Width += r.counter.MinSize().Width + theme.Padding()
+	}
+	return min
 }
 
 func (r *formFieldRenderer) MinSize() fyne.Size {
 	min := r.fieldWidget.MinSize()
+	if r.formField.hasSideLabel() {
+		hintMin := r.hintMinSize()
+		min.Height += hintMin.Height
+		min.Width = fyne.Max(min.Width, theme.Padding()*4+hintMin.Width)
+		return min
+	}
 	stackedLabelTextSize, _ := r.stackedLabelProps()
 	labelMin := fyne.MeasureText(r.label.Text, stackedLabelTextSize, r.label.TextStyle)
-	hintMin := r.hint.MinSize()
+	hintMin := r.hintMinSize()
 	min.Height += labelMin.Height - theme.InputBorderSize()*2
 	min.Height += hintMin.Height
 	min.Width = fyne.Max(min.Width, theme.Padding()*4+labelMin.Width)
@@ -158,7 +439,7 @@ func (r *formFieldRenderer) Objects() []fyne.CanvasObject {
 }
 
 func (r *formFieldRenderer) Refresh() {
-	if r.isFieldFocused() || !r.isFieldEmpty() {
+	if r.isFieldFocused() || (!r.isFieldEmpty() && !r.keepPristine) {
 		r.formField.dirty = true
 	}
 	focusedAppearance := r.isFieldFocused() && !r.formField.Disabled()
@@ -175,6 +456,8 @@ func (r *formFieldRenderer) Refresh() {
 	r.labelBg.Refresh()
 
 	r.label.Text = r.formField.Label
+	r.label.Hidden = r.formField.hasSideLabel()
+	r.labelBg.Hidden = r.label.Hidden
 	if focusedAppearance {
 		r.label.Color = theme.PrimaryColor()
 	} else {
@@ -182,7 +465,7 @@ func (r *formFieldRenderer) Refresh() {
 	}
 
 	r.hint.TextSize = hintTextSize()
-	if !r.isFieldFocused() && !r.formField.Disabled() && r.formField.dirty && r.formField.validationError != nil {
+	if r.formField.isErrorVisible(r.isFieldFocused()) {
 		r.hint.Text = r.formField.validationError.Error()
 		r.hint.Color = theme.ErrorColor()
 		r.label.Color = theme.ErrorColor()
@@ -190,10 +473,26 @@ func (r *formFieldRenderer) Refresh() {
 		r.hint.Text = r.formField.Hint
 		r.hint.Color = theme.PlaceHolderColor()
 	}
+	r.refreshCounter()
 	r.label.Refresh()
 	r.hint.Refresh()
 }
 
+func (r *formFieldRenderer) refreshCounter() {
+	cf, ok := r.formField.impl.(counterField)
+	if !ok {
+		return
+	}
+	text, color, visible := cf.counter()
+	r.counter.Text = text
+	r.counter.Color = color
+	r.counter.TextSize = hintTextSize()
+	r.counter.Hidden = !visible
+	r.counter.Refresh()
+	// the counter width changes with its text.
+	r.layoutHint(r.hint.Position().Y, r.formField.Size().Width)
+}
+
 // InsetPad for Label and Hint text inside the field
 func (r *formFieldRenderer) fieldInsetPad() float32 {
 	return 2 * theme.Padding()
@@ -210,6 +509,15 @@ func (r *formFieldRenderer) nonStackedLabelProps() (textSize float32, posY float
 		(r.MinSize().Height - theme.InputBorderSize() - r.hint.MinSize().Height - nonStackedMinHeight) / 2
 }
 
+// nonStackedLabelPosX returns the label x position when it is shown inside
+// the input, after the leading content of the field (if any).
+func (r *formFieldRenderer) nonStackedLabelPosX() float32 {
+	if af, ok := r.formField.impl.(adornedField); ok {
+		return r.fieldInsetPad() + af.leadingInset()
+	}
+	return r.fieldInsetPad()
+}
+
 func hintTextSize() float32 {
 	return theme.CaptionTextSize() - 1
 }
@@ -343,23 +651,26 @@ func (r *formFieldRenderer) newLabelAnimation() *labelAnimation {
 func (a *labelAnimation) animate(reverse bool) {
 	startTextSize, startPosY := a.renderer.nonStackedLabelProps()
 	endTextSize, endPosY := a.renderer.stackedLabelProps()
+	startPosX, endPosX := a.renderer.nonStackedLabelPosX(), a.renderer.fieldInsetPad()
 	deltaTextSize := endTextSize - startTextSize
 	deltaPosY := endPosY - startPosY
+	deltaPosX := endPosX - startPosX
 	if reverse {
 		startTextSize, endTextSize = endTextSize, startTextSize
 		startPosY, endPosY = endPosY, startPosY
+		startPosX, endPosX = endPosX, startPosX
 		deltaTextSize = -deltaTextSize
 		deltaPosY = -deltaPosY
+		deltaPosX = -deltaPosX
 	}
-	if a.renderer.label.Position().Y == endPosY {
+	if a.renderer.label.Position() == fyne.NewPos(endPosX, endPosY) {
 		// return because it is already in the final position.
 		return
 	}
-	insetPad := a.renderer.fieldInsetPad()
-	a.renderer.label.Move(fyne.NewPos(insetPad, startPosY))
+	a.renderer.label.Move(fyne.NewPos(startPosX, startPosY))
 	a.anim.Tick = func(v float32) {
 		a.renderer.label.TextSize = startTextSize + deltaTextSize*v
-		a.renderer.label.Move(fyne.NewPos(insetPad, startPosY+deltaPosY*v))
+		a.renderer.label.Move(fyne.NewPos(startPosX+deltaPosX*v, startPosY+deltaPosY*v))
 		a.renderer.label.Refresh()
 	}
 	a.anim.Curve = fyne.AnimationEaseOut
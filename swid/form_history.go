@@ -0,0 +1,158 @@
+package swid
+
+import (
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+)
+
+// formHistory keeps the edit history of a form.
+type formHistory struct {
+	coalesce time.Duration
+	values   map[draftField]string
+	undo     []*historyEntry
+	redo     []*historyEntry
+	applying bool
+}
+
+// historyEntry defines a change of a field value.
+type historyEntry struct {
+	field    draftField
+	old, new string
+	time     time.Time
+}
+
+// EnableHistory enables the edit history of the form, so the field value
+// changes can be undone and redone. Consecutive changes of the same field
+// made within the coalesce duration (like typing a word) are recorded as
+// a single change. Password fields are not recorded.
+//
+// The history can be used through Undo and Redo, or with the Ctrl+Z,
+// Ctrl+Shift+Z and Ctrl+Y shortcuts while a form field is focused.
+func (f *Form) EnableHistory(coalesce time.Duration) {
+	f.history = &formHistory{coalesce: coalesce, values: map[draftField]string{}}
+	f.history.snapshot(f.valueFields())
+}
+
+// CanUndo returns true if there is a change that can be undone.
+func (f *Form) CanUndo() bool {
+	return f.history != nil && len(f.history.undo) > 0
+}
+
+// CanRedo returns true if there is a change that can be redone.
+func (f *Form) CanRedo() bool {
+	return f.history != nil && len(f.history.redo) > 0
+}
+
+// Undo reverts the last change and focuses the changed field. It returns
+// false if there is nothing to undo.
+func (f *Form) Undo() bool {
+	if !f.CanUndo() {
+		return false
+	}
+	h := f.history
+	e := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	h.redo = append(h.redo, e)
+	f.applyHistoryValue(e.field, e.old)
+	return true
+}
+
+// Redo applies again the last undone change and focuses the changed field.
+// It returns false if there is nothing to redo.
+func (f *Form) Redo() bool {
+	if !f.CanRedo() {
+		return false
+	}
+	h := f.history
+	e := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	h.undo = append(h.undo, e)
+	f.applyHistoryValue(e.field, e.new)
+	return true
+}
+
+func (f *Form) applyHistoryValue(field draftField, v string) {
+	f.history.applying = true
+	field.restoreDraftValue(v)
+	f.history.applying = false
+	f.history.values[field] = v
+	if ff, ok := field.(FormField); ok {
+		focusField(ff)
+	}
+}
+
+// recordHistory records the fields whose value has changed since the
+// last call.
+func (f *Form) recordHistory() {
+	h := f.history
+	if h == nil || h.applying {
+		return
+	}
+	now := time.Now()
+	for _, field := range f.valueFields() {
+		v, _ := field.draftValue()
+		old, ok := h.values[field]
+		h.values[field] = v
+		if !ok || old == v {
+			continue
+		}
+		h.redo = nil
+		if n := len(h.undo); n > 0 {
+			last := h.undo[n-1]
+			if last.field == field && now.Sub(last.time) <= h.coalesce {
+				last.new = v
+				last.time = now
+				continue
+			}
+		}
+		h.undo = append(h.undo, &historyEntry{field: field, old: old, new: v, time: now})
+	}
+}
+
+func (h *formHistory) snapshot(fields []draftField) {
+	for _, field := range fields {
+		h.values[field], _ = field.draftValue()
+	}
+}
+
+// valueFields returns the fields (including the ones of nested forms)
+// whose values can be stored.
+func (f *Form) valueFields() []draftField {
+	fields := make([]draftField, 0, len(f.fields))
+	for _, field := range f.fields {
+		switch ff := field.(type) {
+		case *Form:
+			fields = append(fields, ff.valueFields()...)
+		case draftField:
+			if _, ok := ff.draftValue(); ok {
+				fields = append(fields, ff)
+			}
+		}
+	}
+	return fields
+}
+
+// typedShortcut handles the shortcuts typed in a form field. It returns
+// true if the shortcut was handled.
+func (f *Form) typedShortcut(s fyne.Shortcut) bool {
+	if f.history != nil {
+		if cs, ok := s.(*desktop.CustomShortcut); ok {
+			ctrl := cs.Modifier&(desktop.ControlModifier|desktop.SuperModifier) != 0
+			shift := cs.Modifier&desktop.ShiftModifier != 0
+			switch {
+			case ctrl && cs.KeyName == fyne.KeyZ && !shift:
+				f.Undo()
+				return true
+			case ctrl && (cs.KeyName == fyne.KeyY || (cs.KeyName == fyne.KeyZ && shift)):
+				f.Redo()
+				return true
+			}
+		}
+	}
+	if f.parent != nil {
+		return f.parent.typedShortcut(s)
+	}
+	return false
+}
@@ -0,0 +1,104 @@
+package swid
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForm_History(t *testing.T) {
+	name := NewTextFormField("Name", "")
+	car := NewSelectFormField("Car", "Audi", []string{"Audi", "Toyota"})
+	street := NewTextFormField("Street", "")
+	f := NewForm(1, name, car, NewForm(1, street))
+	f.EnableHistory(time.Hour)
+
+	w := test.NewWindow(f)
+	defer w.Close()
+
+	assert.False(t, f.CanUndo())
+	assert.False(t, f.Undo())
+
+	w.Canvas().Focus(name.textField)
+	test.Type(name.textField, "Peter")
+	car.selectField.SetSelected("Toyota")
+	street.SetText("Av. Big one")
+	assert.True(t, f.CanUndo())
+
+	assert.True(t, f.Undo())
+	assert.Equal(t, "", street.Text())
+	assert.Equal(t, street.textField, w.Canvas().Focused())
+
+	assert.True(t, f.Undo())
+	assert.Equal(t, "Audi", car.Selected())
+
+	// the typing burst is coalesced in a single change.
+	assert.True(t, f.Undo())
+	assert.Equal(t, "", name.Text())
+	assert.Equal(t, name.textField, w.Canvas().Focused())
+	assert.False(t, f.CanUndo())
+
+	assert.True(t, f.Redo())
+	assert.Equal(t, "Peter", name.Text())
+	assert.True(t, f.Redo())
+	assert.Equal(t, "Toyota", car.Selected())
+
+	// a new change clears the redo history.
+	name.SetText("Paul")
+	assert.False(t, f.CanRedo())
+}
+
+func TestForm_HistoryShortcuts(t *testing.T) {
+	name := NewTextFormField("Name", "")
+	f := NewForm(1, name)
+	f.EnableHistory(0)
+
+	w := test.NewWindow(f)
+	defer w.Close()
+
+	w.Canvas().Focus(name.textField)
+	test.Type(name.textField, "ab")
+	assert.Equal(t, "ab", name.Text())
+
+	undo := &desktop.CustomShortcut{KeyName: fyne.KeyZ, Modifier: desktop.ControlModifier}
+	redo := &desktop.CustomShortcut{KeyName: fyne.KeyZ, Modifier: desktop.ControlModifier | desktop.ShiftModifier}
+
+	name.textField.TypedShortcut(undo)
+	assert.Equal(t, "a", name.Text())
+	name.textField.TypedShortcut(undo)
+	assert.Equal(t, "", name.Text())
+	name.textField.TypedShortcut(redo)
+	assert.Equal(t, "a", name.Text())
+	name.textField.TypedShortcut(&desktop.CustomShortcut{KeyName: fyne.KeyY, Modifier: desktop.ControlModifier})
+	assert.Equal(t, "ab", name.Text())
+}
+
+func TestForm_HistoryShortcuts_NonEntryFields(t *testing.T) {
+	rating := NewRatingFormField("Rating", 5, 0)
+	tags := NewTagsFormField("Tags", nil)
+	f := NewForm(1, rating, tags)
+	f.EnableHistory(0)
+
+	w := test.NewWindow(f)
+	defer w.Close()
+
+	undo := &desktop.CustomShortcut{KeyName: fyne.KeyZ, Modifier: desktop.ControlModifier}
+	redo := &desktop.CustomShortcut{KeyName: fyne.KeyY, Modifier: desktop.ControlModifier}
+
+	w.Canvas().Focus(rating.field)
+	rating.field.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight})
+	assert.Equal(t, 1.0, rating.Rating())
+	rating.field.TypedShortcut(undo)
+	assert.Equal(t, 0.0, rating.Rating())
+	rating.field.TypedShortcut(redo)
+	assert.Equal(t, 1.0, rating.Rating())
+
+	w.Canvas().Focus(tags.field.input)
+	assert.NoError(t, tags.AddTag("go"))
+	tags.field.input.TypedShortcut(undo)
+	assert.Empty(t, tags.Tags())
+}
@@ -0,0 +1,278 @@
+package swid
+
+import (
+	"math"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// LabelPosition defines where the labels of the form fields are shown.
+type LabelPosition int
+
+// LabelPosition options
+const (
+	// LabelPositionFloating shows the label inside the field, floating
+	// above the input when it has content or focus.
+	LabelPositionFloating LabelPosition = iota
+	// LabelPositionBeside shows the label in a column beside the field.
+	// The label column is aligned across all the sections of the form.
+	LabelPositionBeside
+)
+
+// NewFormSection creates a new form to be used as a titled section of
+// another form. The title is shown above the fields with a separator.
+func NewFormSection(title string, cols int, fields ...FormField) *Form {
+	f := NewForm(cols, fields...)
+	f.Title = title
+	return f
+}
+
+// SetColumnSpan sets the number of columns used by the field. The span is
+// limited to the form columns and it is ignored when the form is collapsed
+// to a single column (see CollapseWidth).
+func (f *Form) SetColumnSpan(field FormField, span int) {
+	if f.spans == nil {
+		f.spans = make(map[FormField]int)
+	}
+	if span <= 1 {
+		delete(f.spans, field)
+	} else {
+		f.spans[field] = span
+	}
+	f.BaseWidget.Refresh()
+}
+
+// labelsBeside returns true if this form or one of its parents shows the
+// labels beside the fields.
+func (f *Form) labelsBeside() bool {
+	for form := f; form != nil; form = form.parent {
+		if form.LabelPosition == LabelPositionBeside {
+			return true
+		}
+	}
+	return false
+}
+
+// sideLabelWidth returns the width of the label column, which is shared by
+// the root form and all its nested forms.
+func (f *Form) sideLabelWidth() float32 {
+	return f.root().maxLabelWidth()
+}
+
+func (f *Form) maxLabelWidth() float32 {
+	width := float32(0)
+	for _, field := range f.fields {
+		switch ff := field.(type) {
+		case *Form:
+			width = fyne.Max(width, ff.maxLabelWidth())
+		case labeledField:
+			size := fyne.MeasureText(ff.fieldLabel(), theme.TextSize(), fyne.TextStyle{})
+			width = fyne.Max(width, size.Width+2*theme.Padding())
+		}
+	}
+	return width
+}
+
+// hasSideLabel returns true if the field label is shown by the form
+// instead of the field itself.
+func (b *BaseFormField) hasSideLabel() bool {
+	return b.form != nil && b.form.labelsBeside()
+}
+
+// ===============================================================
+// Layout
+// ===============================================================
+
+// formLayout arranges the fields of a form in a grid. Like the fyne grid
+// layout, all the columns have the same width, but a field can span multiple
+// columns and it can have a label beside it. Each row is as tall as the
+// largest field in it and any extra height is given to the last row.
+type formLayout struct {
+	form   *Form
+	labels map[FormField]*widget.Label
+}
+
+type formCell struct {
+	field FormField
+	label *widget.Label
+	row   int
+	col   int
+	span  int
+}
+
+func newFormLayout(form *Form) *formLayout {
+	return &formLayout{form: form, labels: make(map[FormField]*widget.Label)}
+}
+
+// objects returns the side labels that must be rendered.
+func (l *formLayout) objects() []fyne.CanvasObject {
+	if !l.form.labelsBeside() {
+		return nil
+	}
+	objects := make([]fyne.CanvasObject, 0, len(l.form.fields))
+	for _, field := range l.form.fields {
+		if _, ok := field.(*Form); ok {
+			continue
+		}
+		lf, ok := field.(labeledField)
+		if !ok {
+			continue
+		}
+		label, ok := l.labels[field]
+		if !ok {
+			label = widget.NewLabel("")
+			l.labels[field] = label
+		}
+		label.SetText(lf.fieldLabel())
+		objects = append(objects, label)
+	}
+	return objects
+}
+
+func (l *formLayout) isCollapsed(width float32) bool {
+	return l.form.CollapseWidth > 0 && width < l.form.CollapseWidth
+}
+
+func (l *formLayout) columns(collapsed bool) int {
+	if collapsed || l.form.cols < 1 {
+		return 1
+	}
+	return l.form.cols
+}
+
+// arrange returns the visible cells and the number of rows.
+func (l *formLayout) arrange(cols int) ([]formCell, int) {
+	cells := make([]formCell, 0, len(l.form.fields))
+	beside := l.form.labelsBeside()
+	row, col := 0, 0
+	for _, field := range l.form.fields {
+		label := l.labels[field]
+		if !beside {
+			label = nil
+		}
+		if label != nil {
+			label.Hidden = !field.Visible()
+		}
+		if !field.Visible() {
+			continue
+		}
+		span := 1
+		if cols > 1 && l.form.spans[field] > 1 {
+			span = l.form.spans[field]
+			if span > cols {
+				span = cols
+			}
+		}
+		if col+span > cols {
+			row++
+			col = 0
+		}
+		cells = append(cells, formCell{field: field, label: label, row: row, col: col, span: span})
+		col += span
+		if col >= cols {
+			row++
+			col = 0
+		}
+	}
+	if col > 0 {
+		row++
+	}
+	return cells, row
+}
+
+func (l *formLayout) layout(pos fyne.Position, size fyne.Size) {
+	cols := l.columns(l.isCollapsed(size.Width))
+	cells, rows := l.arrange(cols)
+	if rows == 0 {
+		return
+	}
+	pad := theme.Padding()
+	labelWidth := float32(0)
+	if l.form.labelsBeside() {
+		labelWidth = l.form.sideLabelWidth()
+	}
+	cellWidth := float64(size.Width-float32(cols-1)*pad) / float64(cols)
+	heights := l.rowHeights(cells, rows)
+	extra := size.Height - float32(rows-1)*pad
+	for _, h := range heights {
+		extra -= h
+	}
+	if extra > 0 {
+		heights[rows-1] += extra
+	}
+	tops := make([]float32, rows)
+	for i := 1; i < rows; i++ {
+		tops[i] = tops[i-1] + heights[i-1] + pad
+	}
+	for _, cell := range cells {
+		x1 := gridLeading(cellWidth, cell.col)
+		y1 := tops[cell.row]
+		x2 := gridTrailing(cellWidth, cell.col+cell.span-1)
+		y2 := y1 + heights[cell.row]
+		if cell.label != nil {
+			cell.label.Move(pos.Add(fyne.NewPos(x1, y1)))
+			cell.label.Resize(fyne.NewSize(labelWidth, cell.label.MinSize().Height))
+			x1 += labelWidth + pad
+		}
+		cell.field.Move(pos.Add(fyne.NewPos(x1, y1)))
+		cell.field.Resize(fyne.NewSize(x2-x1, y2-y1))
+	}
+}
+
+func (l *formLayout) minSize() fyne.Size {
+	collapsed := l.isCollapsed(l.form.Size().Width)
+	cols := l.columns(collapsed)
+	cells, rows := l.arrange(cols)
+	if rows == 0 {
+		return fyne.NewSize(0, 0)
+	}
+	pad := theme.Padding()
+	labelWidth := float32(0)
+	if l.form.labelsBeside() {
+		labelWidth = l.form.sideLabelWidth()
+	}
+	cellWidth := float32(0)
+	for _, cell := range cells {
+		width := cell.field.MinSize().Width
+		if cell.label != nil {
+			width += labelWidth + pad
+		}
+		cellWidth = fyne.Max(cellWidth, (width-float32(cell.span-1)*pad)/float32(cell.span))
+	}
+	height := float32(rows-1) * pad
+	for _, h := range l.rowHeights(cells, rows) {
+		height += h
+	}
+	if l.form.CollapseWidth > 0 {
+		// the form can shrink until it is collapsed to a single column.
+		cols = 1
+	}
+	return fyne.NewSize(cellWidth*float32(cols)+float32(cols-1)*pad, height)
+}
+
+// rowHeights returns the minimum height of each row, which is the largest
+// minimum height of its fields and side labels.
+func (l *formLayout) rowHeights(cells []formCell, rows int) []float32 {
+	heights := make([]float32, rows)
+	for _, cell := range cells {
+		height := cell.field.MinSize().Height
+		if cell.label != nil {
+			height = fyne.Max(height, cell.label.MinSize().Height)
+		}
+		heights[cell.row] = fyne.Max(heights[cell.row], height)
+	}
+	return heights
+}
+
+// gridLeading returns the leading edge of a grid cell, like the fyne grid
+// layout does.
+func gridLeading(size float64, offset int) float32 {
+	return float32(math.Round((size + float64(theme.Padding())) * float64(offset)))
+}
+
+// gridTrailing returns the trailing edge of a grid cell.
+func gridTrailing(size float64, offset int) float32 {
+	return gridLeading(size, offset+1) - theme.Padding()
+}
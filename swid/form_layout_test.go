@@ -0,0 +1,102 @@
+package swid
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForm_ColumnSpan(t *testing.T) {
+	name := NewTextFormField("Name", "")
+	age := NewTextFormField("Age", "")
+	address := NewTextFormField("Address", "")
+	f := NewForm(2, name, age, address)
+	f.SetColumnSpan(address, 2)
+
+	w := test.NewWindow(f)
+	defer w.Close()
+	w.Resize(fyne.NewSize(400, 300))
+
+	assert.Equal(t, name.Position().Y, age.Position().Y)
+	assert.Greater(t, age.Position().X, name.Position().X)
+	assert.Greater(t, address.Position().Y, name.Position().Y)
+	assert.Equal(t, name.Position().X, address.Position().X)
+	assert.Equal(t, age.Position().X+age.Size().Width, address.Size().Width)
+}
+
+func TestForm_CollapseWidth(t *testing.T) {
+	name := NewTextFormField("Name", "")
+	age := NewTextFormField("Age", "")
+	f := NewForm(2, name, age)
+	f.CollapseWidth = 300
+
+	w := test.NewWindow(f)
+	defer w.Close()
+
+	w.Resize(fyne.NewSize(500, 300))
+	assert.Equal(t, name.Position().Y, age.Position().Y)
+
+	w.Resize(fyne.NewSize(250, 300))
+	assert.Equal(t, name.Position().X, age.Position().X)
+	assert.Greater(t, age.Position().Y, name.Position().Y)
+}
+
+func TestForm_LabelPositionBeside(t *testing.T) {
+	name := NewTextFormField("Name", "")
+	street := NewTextFormField("Street address", "")
+	section := NewFormSection("Address", 1, street)
+	f := NewForm(1, name, section)
+	f.LabelPosition = LabelPositionBeside
+
+	w := test.NewWindow(f)
+	defer w.Close()
+	w.Resize(fyne.NewSize(500, 400))
+
+	// the label column is shared with the nested section.
+	assert.Equal(t, f.sideLabelWidth(), section.sideLabelWidth())
+	labelWidth := fyne.MeasureText("Street address", theme.TextSize(), fyne.TextStyle{}).Width + 2*theme.Padding()
+	assert.Equal(t, labelWidth+theme.Padding(), name.Position().X)
+	assert.Equal(t, name.Position().X, street.Position().X)
+	assert.True(t, name.hasSideLabel())
+	assert.True(t, street.hasSideLabel())
+
+	r := test.WidgetRenderer(f).(*formRenderer)
+	label := r.layout.labels[name]
+	if assert.NotNil(t, label) {
+		assert.Equal(t, "Name", label.Text)
+	}
+
+	// the section title is shown above its fields.
+	sr := test.WidgetRenderer(section).(*formRenderer)
+	assert.Equal(t, "Address", sr.title.Text)
+	assert.Greater(t, street.Position().Y, sr.title.Position().Y)
+
+	name.Hide()
+	f.Refresh()
+	assert.True(t, label.Hidden)
+}
+
+func TestForm_RowHeights(t *testing.T) {
+	name := NewTextFormField("Name", "")
+	street := NewTextFormField("Street", "")
+	city := NewTextFormField("City", "")
+	section := NewFormSection("Address", 1, street, city)
+	notes := NewTextFormField("Notes", "")
+	f := NewForm(1, name, section, notes)
+
+	w := test.NewWindow(f)
+	defer w.Close()
+	w.Resize(fyne.NewSize(400, 600))
+
+	// each row is as tall as its fields, the extra height goes to the last row.
+	assert.Equal(t, name.MinSize().Height, name.Size().Height)
+	assert.Equal(t, section.MinSize().Height, section.Size().Height)
+	assert.Greater(t, notes.Size().Height, notes.MinSize().Height)
+	assert.Equal(t, section.Position().Y+section.Size().Height+theme.Padding(), notes.Position().Y)
+
+	min := f.MinSize()
+	assert.Less(t, min.Height, 2*section.MinSize().Height+name.MinSize().Height)
+}
@@ -0,0 +1,207 @@
+package swid
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+)
+
+// EnterAction defines what happens when the Enter key is typed in a
+// single-line form field.
+type EnterAction int
+
+// EnterAction options
+const (
+	// EnterActionDefault moves the focus to the next field, or triggers the
+	// first enabled submit button if the field is the last one.
+	EnterActionDefault EnterAction = iota
+	// EnterActionNone keeps the default behavior of the internal widget.
+	EnterActionNone
+	// EnterActionNext always moves the focus to the next field.
+	EnterActionNext
+	// EnterActionSubmit always triggers the first enabled submit button.
+	EnterActionSubmit
+)
+
+// fieldKeyHandler handles the navigation keys typed in the internal widget
+// of a form field.
+type fieldKeyHandler interface {
+	// capturesTab returns true if the Tab key must be handled by the form
+	// instead of the driver.
+	capturesTab() bool
+	// typedKey returns true if the key was handled.
+	typedKey(key fyne.KeyName, shift bool) bool
+	// typedShortcut returns true if the shortcut was handled.
+	typedShortcut(s fyne.Shortcut) bool
+}
+
+// fieldKeys is embedded in the internal widget of a form field to send the
+// navigation keys and the shortcuts typed in it to the form.
+type fieldKeys struct {
+	shiftDown  bool
+	keyHandler fieldKeyHandler
+}
+
+// AcceptsTab implements fyne.Tabbable.
+func (k *fieldKeys) AcceptsTab() bool {
+	return k.keyHandler != nil && k.keyHandler.capturesTab()
+}
+
+// KeyDown implements desktop.Keyable.
+func (k *fieldKeys) KeyDown(key *fyne.KeyEvent) {
+	if key.Name == desktop.KeyShiftLeft || key.Name == desktop.KeyShiftRight {
+		k.shiftDown = true
+	}
+}
+
+// KeyUp implements desktop.Keyable.
+func (k *fieldKeys) KeyUp(key *fyne.KeyEvent) {
+	if key.Name == desktop.KeyShiftLeft || key.Name == desktop.KeyShiftRight {
+		k.shiftDown = false
+	}
+}
+
+// TypedShortcut implements fyne.Shortcutable, so the parent form handles
+// the shortcuts (like undo and redo).
+func (k *fieldKeys) TypedShortcut(s fyne.Shortcut) {
+	if k.keyHandler != nil {
+		k.keyHandler.typedShortcut(s)
+	}
+}
+
+// typedNavigationKey sends a navigation key to the form. It returns true if
+// the key was handled. While the form captures the Tab key, it is handled
+// even if the focus can not move, so it is never typed in the widget.
+func (k *fieldKeys) typedNavigationKey(key fyne.KeyName) bool {
+	if k.keyHandler == nil || !isNavigationKey(key) {
+		return false
+	}
+	if k.keyHandler.typedKey(key, k.shiftDown) {
+		return true
+	}
+	return key == fyne.KeyTab && k.keyHandler.capturesTab()
+}
+
+// SetFocusOrder defines the order in which the fields are focused with the
+// keyboard. The fields that are not included can not be focused with the
+// Tab or Enter keys. Calling it without fields restores the default order
+// (the order of the form fields).
+func (f *Form) SetFocusOrder(fields ...FormField) {
+	if len(fields) == 0 {
+		f.focusOrder = nil
+		return
+	}
+	f.focusOrder = fields
+}
+
+// root returns the outermost form.
+func (f *Form) root() *Form {
+	root := f
+	for root.parent != nil {
+		root = root.parent
+	}
+	return root
+}
+
+// keyboardFields returns the fields that can be focused with the keyboard,
+// in focus order.
+func (f *Form) keyboardFields() []FormField {
+	fields := f.fields
+	if f.focusOrder != nil {
+		fields = f.focusOrder
+	}
+	order := make([]FormField, 0, len(fields))
+	for _, field := range fields {
+		if f.inactive[field] || !field.Visible() {
+			continue
+		}
+		if nested, ok := field.(*Form); ok {
+			order = append(order, nested.keyboardFields()...)
+			continue
+		}
+		if d, ok := field.(fyne.Disableable); ok && d.Disabled() {
+			continue
+		}
+		if _, ok := field.(focusableField); ok {
+			order = append(order, field)
+		}
+	}
+	return order
+}
+
+// navigate moves the focus from the field according to the typed key.
+// It returns true if the key was handled.
+func (f *Form) navigate(field FormField, key fyne.KeyName, shift bool, action EnterAction) bool {
+	root := f.root()
+	if root.DisableKeyboardNavigation {
+		return false
+	}
+	switch key {
+	case fyne.KeyTab:
+		if shift {
+			return root.focusPrevious(field)
+		}
+		return root.focusNext(field, true)
+	case fyne.KeyReturn, fyne.KeyEnter:
+		switch action {
+		case EnterActionNone:
+			return false
+		case EnterActionNext:
+			return root.focusNext(field, true)
+		case EnterActionSubmit:
+			return root.submit()
+		}
+		if root.focusNext(field, false) {
+			return true
+		}
+		return root.submit()
+	}
+	return false
+}
+
+// focusNext focuses the field after the specified one. If wrap is true,
+// the first field is focused when the specified field is the last one.
+func (f *Form) focusNext(field FormField, wrap bool) bool {
+	order := f.keyboardFields()
+	i := indexOfField(order, field)
+	if i+1 < len(order) {
+		return focusField(order[i+1])
+	}
+	if wrap && len(order) > 0 && order[0] != field {
+		return focusField(order[0])
+	}
+	return false
+}
+
+// focusPrevious focuses the field before the specified one, wrapping to
+// the last field.
+func (f *Form) focusPrevious(field FormField) bool {
+	order := f.keyboardFields()
+	if len(order) == 0 {
+		return false
+	}
+	i := indexOfField(order, field)
+	if i > 0 {
+		return focusField(order[i-1])
+	}
+	if last := order[len(order)-1]; last != field {
+		return focusField(last)
+	}
+	return false
+}
+
+// submit triggers the first visible and enabled submit button.
+func (f *Form) submit() bool {
+	for _, btn := range f.submitButtons {
+		if btn.Visible() && !btn.Disabled() && btn.OnTapped != nil {
+			btn.OnTapped()
+			return true
+		}
+	}
+	return false
+}
+
+// isNavigationKey returns true if the key is used to move between
+// form fields.
+func isNavigationKey(key fyne.KeyName) bool {
+	return key == fyne.KeyTab || key == fyne.KeyReturn || key == fyne.KeyEnter
+}
@@ -0,0 +1,88 @@
+package swid
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForm_KeyboardNavigation(t *testing.T) {
+	name := NewTextFormField("Name", "")
+	car := NewSelectFormField("Car", "Audi", []string{"Audi", "Toyota"})
+	street := NewTextFormField("Street", "")
+	disabled := NewTextFormField("Disabled", "")
+	disabled.Disable()
+	f := NewForm(1, name, car, NewForm(1, street, disabled))
+	submitted := false
+	f.CreateSubmitButton("Submit", func() { submitted = true })
+
+	w := test.NewWindow(f)
+	defer w.Close()
+	c := w.Canvas()
+
+	assert.True(t, name.textField.AcceptsTab())
+	c.Focus(name.textField)
+	name.textField.TypedKey(&fyne.KeyEvent{Name: fyne.KeyTab})
+	assert.Equal(t, car.selectField, c.Focused())
+	car.selectField.TypedKey(&fyne.KeyEvent{Name: fyne.KeyTab})
+	assert.Equal(t, street.textField, c.Focused())
+	// disabled fields are skipped and the focus wraps around.
+	street.textField.TypedKey(&fyne.KeyEvent{Name: fyne.KeyTab})
+	assert.Equal(t, name.textField, c.Focused())
+
+	name.textField.KeyDown(&fyne.KeyEvent{Name: desktop.KeyShiftLeft})
+	name.textField.TypedKey(&fyne.KeyEvent{Name: fyne.KeyTab})
+	name.textField.KeyUp(&fyne.KeyEvent{Name: desktop.KeyShiftLeft})
+	assert.Equal(t, street.textField, c.Focused())
+
+	// enter on the last field submits the form.
+	street.textField.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	assert.True(t, submitted)
+
+	submitted = false
+	name.EnterAction = EnterActionSubmit
+	c.Focus(name.textField)
+	name.textField.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	assert.True(t, submitted)
+
+	f.SetFocusOrder(street, name)
+	c.Focus(street.textField)
+	street.textField.TypedKey(&fyne.KeyEvent{Name: fyne.KeyTab})
+	assert.Equal(t, name.textField, c.Focused())
+
+	f.DisableKeyboardNavigation = true
+	assert.False(t, name.textField.AcceptsTab())
+}
+
+func TestForm_KeyboardNavigation_MultiLine(t *testing.T) {
+	notes := NewTextFormField("Notes", "")
+	notes.textField.MultiLine = true
+	name := NewTextFormField("Name", "")
+	f := NewForm(1, notes, name)
+
+	w := test.NewWindow(f)
+	defer w.Close()
+
+	w.Canvas().Focus(notes.textField)
+	notes.textField.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	assert.Equal(t, notes.textField, w.Canvas().Focused())
+	assert.Equal(t, "\n", notes.Text())
+}
+
+func TestForm_KeyboardNavigation_TabWithoutNextField(t *testing.T) {
+	name := NewTextFormField("Name", "ab")
+	f := NewForm(1, name)
+	w := test.NewWindow(f)
+	defer w.Close()
+
+	w.Canvas().Focus(name.textField)
+	name.textField.CursorColumn = 0
+	assert.True(t, name.textField.AcceptsTab())
+	// there is no other field to move to, but the tab is not typed.
+	name.textField.TypedKey(&fyne.KeyEvent{Name: fyne.KeyTab})
+	assert.Equal(t, "ab", name.Text())
+	assert.Equal(t, name.textField, w.Canvas().Focused())
+}
@@ -281,3 +281,206 @@ func TestForm_MultipleSubmitButtons(t *testing.T) {
 	test.Tap(rstButton)
 	test.AssertImageMatches(t, "form/multi_submitbtn_initial.png", w.Canvas().Capture())
 }
+
+func TestForm_AddRemoveField(t *testing.T) {
+	name := NewTextFormField("Name", "Peter")
+	name.Validator = svalid.NotEmpty()
+
+	f := NewForm(1, name)
+	submitButton := f.CreateSubmitButton("Create", func() {})
+	w := test.NewWindow(container.NewVBox(f, submitButton))
+	defer w.Close()
+
+	assert.True(t, f.IsValid())
+	assert.False(t, submitButton.Disabled())
+
+	lastName := NewTextFormField("LastName", "")
+	lastName.Validator = svalid.NotEmpty()
+	f.AddField(lastName)
+	assert.False(t, f.IsValid())
+	assert.True(t, submitButton.Disabled())
+	assert.Len(t, test.WidgetRenderer(f).Objects(), 2)
+
+	age := NewTextFormField("Age", "18")
+	f.InsertField(0, age)
+	assert.Equal(t, []fyne.CanvasObject{age, name, lastName}, test.WidgetRenderer(f).Objects())
+
+	changed := 0
+	f.OnChanged = func() { changed++ }
+	lastName.SetText("Parker")
+	assert.NotZero(t, changed)
+	assert.True(t, f.IsValid())
+	assert.False(t, submitButton.Disabled())
+
+	lastName.SetText("")
+	assert.True(t, submitButton.Disabled())
+	assert.True(t, f.RemoveField(lastName))
+	assert.False(t, f.RemoveField(lastName))
+	assert.True(t, f.IsValid())
+	assert.False(t, submitButton.Disabled())
+	assert.Equal(t, []fyne.CanvasObject{age, name}, test.WidgetRenderer(f).Objects())
+
+	// a removed field must not notify the form anymore.
+	changed = 0
+	lastName.SetText("Pérez")
+	assert.Zero(t, changed)
+}
+
+func TestCustomForm_RescanOnRefresh(t *testing.T) {
+	name := NewTextFormField("Name", "Peter")
+	name.Validator = svalid.NotEmpty()
+
+	cont := container.NewVBox(name)
+	f := NewCustomForm(cont)
+	f.RescanOnRefresh = true
+	w := test.NewWindow(f)
+	defer w.Close()
+
+	assert.True(t, f.IsValid())
+
+	lastName := NewTextFormField("LastName", "")
+	lastName.Validator = svalid.NotEmpty()
+	cont.Add(lastName)
+	f.Refresh()
+	assert.False(t, f.IsValid())
+
+	saved := ""
+	lastName.OnSaved = func(s string) { saved = s }
+	lastName.SetText("Parker")
+	assert.True(t, f.IsValid())
+	f.Save()
+	assert.Equal(t, "Parker", saved)
+
+	lastName.SetText("")
+	assert.False(t, f.IsValid())
+	cont.Remove(lastName)
+	f.Refresh()
+	assert.True(t, f.IsValid())
+}
+
+func TestForm_Nested(t *testing.T) {
+	var data struct {
+		Name   string
+		Street string
+		City   string
+	}
+
+	street := NewTextFormField("Street", "")
+	street.Validator = svalid.NotEmpty()
+	street.OnSaved = func(s string) { data.Street = s }
+
+	city := NewTextFormField("City", "Machala")
+	city.Validator = svalid.NotEmpty()
+	city.OnSaved = func(s string) { data.City = s }
+
+	address := NewForm(2, street, city)
+
+	name := NewTextFormField("Name", "Peter")
+	name.Validator = svalid.NotEmpty()
+	name.OnSaved = func(s string) { data.Name = s }
+
+	f := NewCustomForm(container.NewVBox(name, address))
+	assert.Len(t, f.fields, 2)
+
+	changed := 0
+	f.OnChanged = func() { changed++ }
+	w := test.NewWindow(f)
+	defer w.Close()
+
+	assert.False(t, f.IsValid())
+	assert.EqualError(t, f.ValidationError(), svalid.NotEmpty()("").Error())
+
+	street.SetText("Av. Big one")
+	assert.NotZero(t, changed)
+	assert.True(t, f.IsValid())
+	assert.NoError(t, f.Validate())
+
+	f.Save()
+	assert.Equal(t, "Peter", data.Name)
+	assert.Equal(t, "Av. Big one", data.Street)
+	assert.Equal(t, "Machala", data.City)
+
+	f.Reset()
+	assert.Equal(t, "", street.Text())
+	assert.False(t, f.IsValid())
+}
+
+func TestForm_Rules(t *testing.T) {
+	var saved []string
+
+	accountType := NewSelectFormField("Account Type", "Basic", []string{"Basic", "Business"})
+	company := NewTextFormField("Company", "")
+	company.Validator = svalid.NotEmpty()
+	company.OnSaved = func(s string) { saved = append(saved, "company:"+s) }
+	taxID := NewTextFormField("Tax ID", "")
+	taxID.Validator = svalid.NotEmpty()
+	taxID.OnSaved = func(s string) { saved = append(saved, "taxID:"+s) }
+
+	f := NewForm(1, accountType, company, taxID)
+	f.ShowWhen(company, func() bool { return accountType.Selected() == "Business" })
+	f.EnableWhen(taxID, func() bool { return accountType.Selected() == "Business" })
+
+	w := test.NewWindow(f)
+	defer w.Close()
+
+	assert.False(t, company.Visible())
+	assert.True(t, taxID.Disabled())
+	assert.True(t, f.IsValid())
+	f.Save()
+	assert.Empty(t, saved)
+	hiddenHeight := f.MinSize().Height
+
+	accountType.selectField.SetSelected("Business")
+	assert.True(t, company.Visible())
+	assert.False(t, taxID.Disabled())
+	assert.False(t, f.IsValid())
+	assert.Greater(t, f.MinSize().Height, hiddenHeight)
+
+	company.SetText("ACME")
+	taxID.SetText("123")
+	assert.True(t, f.IsValid())
+	f.Save()
+	assert.Equal(t, []string{"company:ACME", "taxID:123"}, saved)
+
+	f.Reset()
+	assert.False(t, company.Visible())
+	assert.True(t, taxID.Disabled())
+	assert.True(t, f.IsValid())
+}
+
+func TestForm_PristineFieldsHideErrors(t *testing.T) {
+	errRequired := errors.New("required")
+	check := NewCheckFormField("Terms", "I accept the terms", false)
+	check.Validator = svalid.Checked()
+	radio := NewRadioGroupFormField("Plan", "", []string{"Free", "Pro"})
+	radio.Validator = svalid.NotEmpty()
+	slider := NewSliderFormField("Age", 0, 100, 0)
+	slider.Validator = func(v float64) error {
+		if v < 18 {
+			return errRequired
+		}
+		return nil
+	}
+	rating := NewRatingFormField("Rating", 5, 0)
+	rating.Validator = func(v float64) error {
+		if v == 0 {
+			return errRequired
+		}
+		return nil
+	}
+	f := NewForm(1, check, radio, slider, rating)
+	w := test.NewWindow(f)
+	defer w.Close()
+
+	fields := []*BaseFormField{&check.BaseFormField, &radio.BaseFormField, &slider.BaseFormField, &rating.BaseFormField}
+	for _, field := range fields {
+		assert.Error(t, field.validationError)
+		assert.False(t, field.dirty)
+		assert.False(t, field.isErrorVisible(false))
+	}
+
+	f.MarkDirty()
+	for _, field := range fields {
+		assert.True(t, field.isErrorVisible(false))
+	}
+}
@@ -0,0 +1,412 @@
+package swid
+
+import (
+	"encoding/json"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// ListItemTemplate creates the form of a ListFormField item. The value
+// parameter is the initial value of the item, it will be nil for the items
+// added by the user. It must return the item form and a function that
+// returns the current value of the item.
+type ListItemTemplate func(value interface{}) (form *Form, getValue func() interface{})
+
+// ListFormField defines a special form field that holds a list of
+// repeatable groups of fields (like "add another phone number").
+type ListFormField struct {
+	BaseFormField
+
+	AddText   string
+	MinItems  int
+	MaxItems  int
+	Validator func(values []interface{}) error
+
+	OnChanged func(values []interface{}) `json:"-"`
+	OnSaved   func(values []interface{})
+
+	listField     *listField
+	addButton     *widget.Button
+	template      ListItemTemplate
+	initialValues []interface{}
+	items         []*listFormItem
+}
+
+type listFormItem struct {
+	form     *Form
+	getValue func() interface{}
+
+	row          *fyne.Container
+	upButton     *widget.Button
+	downButton   *widget.Button
+	removeButton *widget.Button
+}
+
+// NewListFormField creates a new list form field. The template is used
+// to create a new item for each initial value and every time the user
+// adds a new item.
+func NewListFormField(label string, template ListItemTemplate, initialValues []interface{}) *ListFormField {
+	l := &ListFormField{}
+	l.ExtendBaseFormField(l)
+	l.Label = label
+	l.AddText = "Add"
+	l.template = template
+	l.initialValues = initialValues
+	l.addButton = widget.NewButtonWithIcon(l.AddText, theme.ContentAddIcon(), func() {
+		l.AddItem(nil)
+	})
+	l.listField = newListField(l)
+	l.setItems(initialValues)
+	return l
+}
+
+// ===============================================================
+// Methods
+// ===============================================================
+
+// Len returns the number of items.
+func (l *ListFormField) Len() int {
+	return len(l.items)
+}
+
+// Values returns the current value of each item.
+func (l *ListFormField) Values() []interface{} {
+	values := make([]interface{}, len(l.items))
+	for i, item := range l.items {
+		values[i] = item.getValue()
+	}
+	return values
+}
+
+// AddItem appends a new item with the specified initial value. It does
+// nothing if the list already has MaxItems items.
+func (l *ListFormField) AddItem(value interface{}) {
+	if l.MaxItems > 0 && len(l.items) >= l.MaxItems {
+		return
+	}
+	l.items = append(l.items, l.newItem(value))
+	l.itemsDidChange()
+}
+
+// RemoveItem removes the item at the specified index. It does nothing
+// if the list has MinItems items or less.
+func (l *ListFormField) RemoveItem(index int) {
+	if index < 0 || index >= len(l.items) || len(l.items) <= l.MinItems {
+		return
+	}
+	l.items[index].form.listeners = nil
+	l.items = append(l.items[:index], l.items[index+1:]...)
+	l.itemsDidChange()
+}
+
+// MoveItem moves the item at the index from to the index to.
+func (l *ListFormField) MoveItem(from, to int) {
+	if from < 0 || from >= len(l.items) || to < 0 || to >= len(l.items) || from == to {
+		return
+	}
+	item := l.items[from]
+	l.items = append(l.items[:from], l.items[from+1:]...)
+	l.items = append(l.items[:to], append([]*listFormItem{item}, l.items[to:]...)...)
+	l.itemsDidChange()
+}
+
+// Reset restores the items created from the initial values.
+func (l *ListFormField) Reset() {
+	l.resetDirty()
+	for _, item := range l.items {
+		item.form.listeners = nil
+	}
+	l.setItems(l.initialValues)
+	l.Validate()
+	l.Refresh()
+	l.didChange()
+}
+
+// Save triggers the Save of each item and then the OnSaved callback
+// with the value of each item.
+func (l *ListFormField) Save() {
+	for _, item := range l.items {
+		item.form.Save()
+	}
+	if l.OnSaved != nil {
+		l.OnSaved(l.Values())
+	}
+}
+
+// draftValue returns the draft values of each item form, encoded as JSON.
+func (l *ListFormField) draftValue() (string, bool) {
+	items := make([]map[string]string, len(l.items))
+	for i, item := range l.items {
+		items[i] = map[string]string{}
+		item.form.draftValues("", items[i])
+	}
+	data, err := json.Marshal(items)
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// restoreDraftValue creates an item for each stored item form, and
+// restores its values.
+func (l *ListFormField) restoreDraftValue(v string) {
+	var values []map[string]string
+	if err := json.Unmarshal([]byte(v), &values); err != nil {
+		return
+	}
+	for _, item := range l.items {
+		item.form.listeners = nil
+	}
+	l.items = make([]*listFormItem, 0, len(values))
+	for _, itemValues := range values {
+		item := l.newItem(nil)
+		item.form.restoreDraftValues("", itemValues)
+		l.items = append(l.items, item)
+	}
+	l.updateListError()
+	l.notifyChange()
+	l.Refresh()
+}
+
+func (l *ListFormField) markDirty() {
+	for _, item := range l.items {
+		item.form.MarkDirty()
+	}
+	l.BaseFormField.markDirty()
+}
+
+// ValidationError returns the list validation error or the first error
+// found in the items.
+func (l *ListFormField) ValidationError() error {
+	if l.validationError != nil {
+		return l.validationError
+	}
+	for _, item := range l.items {
+		if err := item.form.firstError(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate validates the list and each item form. The fields that are
+// hidden or disabled by the rules of their item form are not validated.
+func (l *ListFormField) Validate() error {
+	var itemsErr error
+	for _, item := range l.items {
+		if err := item.form.Validate(); err != nil && itemsErr == nil {
+			itemsErr = err
+		}
+	}
+	l.updateListError()
+	if l.validationError != nil {
+		return l.validationError
+	}
+	return itemsErr
+}
+
+func (l *ListFormField) setItems(values []interface{}) {
+	l.items = make([]*listFormItem, 0, len(values))
+	for _, v := range values {
+		l.items = append(l.items, l.newItem(v))
+	}
+}
+
+func (l *ListFormField) newItem(value interface{}) *listFormItem {
+	form, getValue := l.template(value)
+	for _, field := range form.fields {
+		field.setParentForm(form)
+	}
+	form.listeners = append(form.listeners, l.itemDidChange)
+
+	item := &listFormItem{form: form, getValue: getValue}
+	item.upButton = widget.NewButtonWithIcon("", theme.MoveUpIcon(), func() {
+		i := l.indexOf(item)
+		l.MoveItem(i, i-1)
+	})
+	item.downButton = widget.NewButtonWithIcon("", theme.MoveDownIcon(), func() {
+		i := l.indexOf(item)
+		l.MoveItem(i, i+1)
+	})
+	item.removeButton = widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+		l.RemoveItem(l.indexOf(item))
+	})
+	item.row = container.NewBorder(nil, nil, nil,
+		container.NewHBox(item.upButton, item.downButton, item.removeButton),
+		form,
+	)
+	return item
+}
+
+func (l *ListFormField) indexOf(item *listFormItem) int {
+	for i, it := range l.items {
+		if it == item {
+			return i
+		}
+	}
+	return -1
+}
+
+// itemDidChange is called when a field of an item changes.
+func (l *ListFormField) itemDidChange() {
+	l.updateListError()
+	l.notifyChange()
+}
+
+// itemsDidChange is called when an item is added, removed or moved.
+func (l *ListFormField) itemsDidChange() {
+	l.dirty = true
+	l.updateListError()
+	l.notifyChange()
+	l.Refresh()
+}
+
+func (l *ListFormField) notifyChange() {
+	if l.OnChanged != nil {
+		l.OnChanged(l.Values())
+	}
+	l.didChange()
+}
+
+func (l *ListFormField) updateListError() {
+	var err error
+	if l.Validator != nil {
+		err = l.Validator(l.Values())
+	}
+	if err != l.validationError {
+		l.validationError = err
+		l.Refresh()
+	}
+}
+
+// ===============================================================
+// Renderer
+// ===============================================================
+
+// CreateRenderer implements fyne.Widget.
+func (l *ListFormField) CreateRenderer() fyne.WidgetRenderer {
+	l.ExtendBaseFormField(l)
+
+	if l.Validator != nil {
+		l.validationError = l.Validator(l.Values())
+	}
+
+	isFieldEmpty := func() bool {
+		return false // the add button is always shown, so keep the label stacked.
+	}
+
+	isFieldFocused := func() bool {
+		return false
+	}
+
+	updateInternalField := func() {
+		l.addButton.SetText(l.AddText)
+		if l.Disabled() || (l.MaxItems > 0 && len(l.items) >= l.MaxItems) {
+			l.addButton.Disable()
+		} else {
+			l.addButton.Enable()
+		}
+		for i, item := range l.items {
+			setButtonEnabled(item.upButton, !l.Disabled() && i > 0)
+			setButtonEnabled(item.downButton, !l.Disabled() && i < len(l.items)-1)
+			setButtonEnabled(item.removeButton, !l.Disabled() && len(l.items) > l.MinItems)
+			for _, field := range item.form.fields {
+				if d, ok := field.(fyne.Disableable); ok {
+					if l.Disabled() || item.form.disabledByRule(field) {
+						d.Disable()
+					} else {
+						d.Enable()
+					}
+				}
+			}
+		}
+		l.listField.Refresh()
+	}
+
+	// the list is dirty only when its items change (see itemsDidChange).
+	return l.createPristineRenderer(
+		l.Label, l.Hint, l.listField,
+		isFieldEmpty, isFieldFocused,
+		updateInternalField,
+	)
+}
+
+func setButtonEnabled(btn *widget.Button, enabled bool) {
+	if enabled {
+		btn.Enable()
+	} else {
+		btn.Disable()
+	}
+}
+
+// ===============================================================
+// List field
+// ===============================================================
+
+// listField is the internal widget that shows the items of a ListFormField.
+type listField struct {
+	widget.BaseWidget
+	list *ListFormField
+}
+
+func newListField(list *ListFormField) *listField {
+	f := &listField{list: list}
+	f.ExtendBaseWidget(f)
+	return f
+}
+
+func (f *listField) CreateRenderer() fyne.WidgetRenderer {
+	f.ExtendBaseWidget(f)
+	bg := canvas.NewRectangle(theme.InputBackgroundColor())
+	content := container.NewVBox()
+	r := &listFieldRenderer{
+		bg:      bg,
+		content: content,
+		widget:  f,
+		objects: []fyne.CanvasObject{bg, content},
+	}
+	r.Refresh()
+	return r
+}
+
+type listFieldRenderer struct {
+	bg      *canvas.Rectangle
+	content *fyne.Container
+	widget  *listField
+	objects []fyne.CanvasObject
+}
+
+func (r *listFieldRenderer) Destroy() {}
+
+func (r *listFieldRenderer) Layout(size fyne.Size) {
+	pad := theme.Padding()
+	r.bg.Resize(size)
+	r.content.Move(fyne.NewPos(pad, pad))
+	r.content.Resize(size.Subtract(fyne.NewSize(2*pad, 2*pad)))
+}
+
+func (r *listFieldRenderer) MinSize() fyne.Size {
+	pad := theme.Padding()
+	return r.content.MinSize().Add(fyne.NewSize(2*pad, 2*pad))
+}
+
+func (r *listFieldRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *listFieldRenderer) Refresh() {
+	r.bg.FillColor = theme.InputBackgroundColor()
+	r.bg.Refresh()
+	items := r.widget.list.items
+	objects := make([]fyne.CanvasObject, 0, len(items)+1)
+	for _, item := range items {
+		objects = append(objects, item.row)
+	}
+	objects = append(objects, r.widget.list.addButton)
+	r.content.Objects = objects
+	r.content.Refresh()
+}
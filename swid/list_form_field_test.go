@@ -0,0 +1,147 @@
+package swid
+
+import (
+	"errors"
+	"testing"
+
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"github.com/fpabl0/sparky-go/svalid"
+	"github.com/stretchr/testify/assert"
+)
+
+func phoneTemplate(value interface{}) (*Form, func() interface{}) {
+	initial, _ := value.(string)
+	phone := NewTextFormField("Phone", initial)
+	phone.Validator = svalid.NotEmpty()
+	return NewForm(1, phone), func() interface{} { return phone.Text() }
+}
+
+func TestListFormField_AddRemoveMove(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	l := NewListFormField("Phones", phoneTemplate, []interface{}{"111", "222"})
+
+	w := test.NewWindow(l)
+	defer w.Close()
+
+	assert.Equal(t, 2, l.Len())
+	assert.Equal(t, []interface{}{"111", "222"}, l.Values())
+
+	l.AddItem("333")
+	assert.Equal(t, []interface{}{"111", "222", "333"}, l.Values())
+
+	l.MoveItem(2, 0)
+	assert.Equal(t, []interface{}{"333", "111", "222"}, l.Values())
+
+	l.RemoveItem(1)
+	assert.Equal(t, []interface{}{"333", "222"}, l.Values())
+
+	test.Tap(l.items[0].downButton)
+	assert.Equal(t, []interface{}{"222", "333"}, l.Values())
+	test.Tap(l.items[1].removeButton)
+	assert.Equal(t, []interface{}{"222"}, l.Values())
+
+	l.MaxItems = 2
+	l.AddItem(nil)
+	l.AddItem(nil)
+	assert.Equal(t, []interface{}{"222", ""}, l.Values())
+	assert.True(t, l.addButton.Disabled())
+
+	l.MinItems = 2
+	l.Refresh()
+	l.RemoveItem(0)
+	assert.Equal(t, 2, l.Len())
+	assert.True(t, l.items[0].removeButton.Disabled())
+}
+
+func TestListFormField_Validation(t *testing.T) {
+	l := NewListFormField("Phones", phoneTemplate, []interface{}{"111"})
+	l.Validator = func(values []interface{}) error {
+		if len(values) < 2 {
+			return errors.New("at least 2 phones")
+		}
+		return nil
+	}
+
+	f := NewForm(1, l)
+	submitButton := f.CreateSubmitButton("Save", func() {})
+	w := test.NewWindow(container.NewVBox(f, submitButton))
+	defer w.Close()
+
+	assert.False(t, f.IsValid())
+	assert.EqualError(t, l.ValidationError(), "at least 2 phones")
+
+	l.AddItem(nil)
+	assert.False(t, f.IsValid())
+	assert.EqualError(t, l.ValidationError(), svalid.NotEmpty()("").Error())
+	assert.True(t, submitButton.Disabled())
+
+	phone := l.items[1].form.fields[0].(*TextFormField)
+	phone.SetText("222")
+	assert.NoError(t, l.ValidationError())
+	assert.True(t, f.IsValid())
+	assert.False(t, submitButton.Disabled())
+}
+
+func TestListFormField_SaveReset(t *testing.T) {
+	l := NewListFormField("Phones", phoneTemplate, []interface{}{"111"})
+	var saved []interface{}
+	l.OnSaved = func(values []interface{}) { saved = values }
+
+	changed := 0
+	l.OnChanged = func([]interface{}) { changed++ }
+
+	l.AddItem("222")
+	assert.Equal(t, 1, changed)
+	l.items[0].form.fields[0].(*TextFormField).SetText("000")
+	assert.NotZero(t, changed)
+
+	l.Save()
+	assert.Equal(t, []interface{}{"000", "222"}, saved)
+
+	l.Reset()
+	l.Save()
+	assert.Equal(t, []interface{}{"111"}, saved)
+}
+
+func TestListFormField_ItemRules(t *testing.T) {
+	template := func(value interface{}) (*Form, func() interface{}) {
+		hasPhone := NewCheckFormField("", "Has phone", false)
+		phone := NewTextFormField("Phone", "")
+		phone.Validator = svalid.NotEmpty()
+		form := NewForm(1, hasPhone, phone)
+		form.EnableWhen(phone, hasPhone.Checked)
+		return form, func() interface{} { return phone.Text() }
+	}
+	l := NewListFormField("Phones", template, []interface{}{nil})
+	f := NewForm(1, l)
+	w := test.NewWindow(f)
+	defer w.Close()
+
+	phone := l.items[0].form.fields[1].(*TextFormField)
+	assert.True(t, phone.Disabled())
+	// the disabled phone is not validated
+	assert.NoError(t, l.Validate())
+	assert.True(t, f.IsValid())
+
+	// refreshing the list keeps the item rules
+	l.Refresh()
+	assert.True(t, phone.Disabled())
+
+	l.Disable()
+	l.Enable()
+	assert.True(t, phone.Disabled())
+}
+
+func TestListFormField_ResetDirty(t *testing.T) {
+	l := NewListFormField("Phones", phoneTemplate, []interface{}{"111"})
+	w := test.NewWindow(l)
+	defer w.Close()
+
+	l.AddItem(nil)
+	assert.True(t, l.dirty)
+	l.Reset()
+	assert.False(t, l.dirty)
+	assert.Equal(t, 1, l.Len())
+}
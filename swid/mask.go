@@ -0,0 +1,207 @@
+package swid
+
+import (
+	"unicode"
+)
+
+// maskCase defines the case conversion of a mask placeholder.
+type maskCase int
+
+const (
+	maskCaseNone maskCase = iota
+	maskCaseUpper
+	maskCaseLower
+)
+
+// maskToken defines a literal or a placeholder of a mask.
+type maskToken struct {
+	char     rune
+	literal  bool
+	optional bool
+	conv     maskCase
+}
+
+// textMask defines a parsed mask. The text of a masked field is the result
+// of formatting its raw value (the characters typed in the placeholders).
+// A literal is only shown when there is a raw character after it.
+type textMask struct {
+	tokens  []maskToken
+	classes map[rune]func(rune) bool
+}
+
+// parseMask parses the mask definition. The classes are the custom
+// placeholders, they are checked before the built-in ones.
+func parseMask(mask []rune, classes map[rune]func(rune) bool) *textMask {
+	m := &textMask{classes: classes}
+	optional := false
+	conv := maskCaseNone
+	for i := 0; i < len(mask); i++ {
+		r := mask[i]
+		switch {
+		case r == '\\' && i+1 < len(mask):
+			i++
+			m.tokens = append(m.tokens, maskToken{char: mask[i], literal: true, optional: optional})
+		case r == '[':
+			optional = true
+		case r == ']':
+			optional = false
+		case r == '>':
+			conv = maskCaseUpper
+		case r == '<':
+			conv = maskCaseLower
+		case r == '|':
+			conv = maskCaseNone
+		case m.isPlaceholder(r):
+			m.tokens = append(m.tokens, maskToken{char: r, optional: optional, conv: conv})
+		default:
+			m.tokens = append(m.tokens, maskToken{char: r, literal: true, optional: optional})
+		}
+	}
+	return m
+}
+
+func (m *textMask) isPlaceholder(r rune) bool {
+	if _, ok := m.classes[r]; ok {
+		return true
+	}
+	return r == '9' || r == 'a' || r == '*'
+}
+
+// isLiteral returns true if the rune is a literal of the mask.
+func (m *textMask) isLiteral(r rune) bool {
+	for _, t := range m.tokens {
+		if t.literal && t.char == r {
+			return true
+		}
+	}
+	return false
+}
+
+// accept returns the rune converted to the case of the placeholder, and
+// false if the placeholder does not accept it.
+func (m *textMask) accept(t maskToken, r rune) (rune, bool) {
+	switch t.conv {
+	case maskCaseUpper:
+		r = unicode.ToUpper(r)
+	case maskCaseLower:
+		r = unicode.ToLower(r)
+	}
+	if accept, ok := m.classes[t.char]; ok {
+		return r, accept(r)
+	}
+	switch t.char {
+	case '9':
+		return r, unicode.IsDigit(r)
+	case 'a':
+		return r, unicode.IsLetter(r)
+	case '*':
+		return r, unicode.IsDigit(r) || unicode.IsLetter(r)
+	}
+	return r, false
+}
+
+// format returns the formatted text of the raw value and the position of
+// each raw character in the text. It returns false if a raw character is
+// not accepted or the raw value is too long.
+func (m *textMask) format(raw []rune) (text []rune, slots []int, ok bool) {
+	text = make([]rune, 0, len(m.tokens))
+	slots = make([]int, 0, len(raw))
+	ri := 0
+	for _, t := range m.tokens {
+		if ri >= len(raw) {
+			return text, slots, true
+		}
+		if t.literal {
+			text = append(text, t.char)
+			continue
+		}
+		r, accepted := m.accept(t, raw[ri])
+		if !accepted {
+			return text, slots, false
+		}
+		slots = append(slots, len(text))
+		text = append(text, r)
+		ri++
+	}
+	return text, slots, ri >= len(raw)
+}
+
+// raw returns the raw value of a text formatted with the mask.
+func (m *textMask) raw(text []rune) []rune {
+	raw := make([]rune, 0, len(text))
+	ti := 0
+	for _, t := range m.tokens {
+		if ti >= len(text) {
+			break
+		}
+		if t.literal {
+			if text[ti] == t.char {
+				ti++
+			}
+			continue
+		}
+		raw = append(raw, text[ti])
+		ti++
+	}
+	return raw
+}
+
+// complete returns true if all the required placeholders have a value.
+func (m *textMask) complete(raw []rune) bool {
+	n := 0
+	for _, t := range m.tokens {
+		if !t.literal && !t.optional {
+			n++
+		}
+	}
+	return len(raw) >= n
+}
+
+// edit replaces raw[start:end] with the input characters that can be
+// placed. It returns the new raw value, the raw position after the
+// inserted characters and the rejected characters (the literals of the
+// mask are skipped without being rejected). It returns false if the
+// edit is not possible.
+func (m *textMask) edit(raw []rune, start, end int, input []rune) ([]rune, int, []rune, bool) {
+	result := append([]rune{}, raw[:start]...)
+	suffix := raw[end:]
+	var rejected []rune
+	for _, r := range input {
+		candidate := append(append(append([]rune{}, result...), r), suffix...)
+		if _, _, ok := m.format(candidate); ok {
+			result = append(result, r)
+			continue
+		}
+		if !m.isLiteral(r) {
+			rejected = append(rejected, r)
+		}
+	}
+	cursor := len(result)
+	result = append(result, suffix...)
+	if _, _, ok := m.format(result); !ok {
+		return raw, start, rejected, false
+	}
+	return result, cursor, rejected, true
+}
+
+// maskTextPos returns the position in the text after the raw position.
+func maskTextPos(slots []int, rawPos int) int {
+	if rawPos <= 0 || len(slots) == 0 {
+		return 0
+	}
+	if rawPos > len(slots) {
+		rawPos = len(slots)
+	}
+	return slots[rawPos-1] + 1
+}
+
+// slotsBefore returns the number of slots placed before the text position.
+func slotsBefore(slots []int, pos int) int {
+	n := 0
+	for _, s := range slots {
+		if s < pos {
+			n++
+		}
+	}
+	return n
+}
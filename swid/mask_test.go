@@ -0,0 +1,78 @@
+package swid
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTextField_MaskFormat(t *testing.T) {
+	tf := NewMaskedTextField("99/99[/9999]", "")
+	tf.SetText("1234")
+	assert.Equal(t, "12/34", tf.Text)
+	assert.Equal(t, "1234", tf.RawText())
+	assert.True(t, tf.MaskComplete())
+
+	tf.SetText("123")
+	assert.False(t, tf.MaskComplete())
+
+	tf.SetText("12/34/2021")
+	assert.Equal(t, "12/34/2021", tf.Text)
+	assert.Equal(t, "12342021", tf.RawText())
+
+	escaped := NewMaskedTextField(`\9-999`, "")
+	escaped.SetText("123")
+	assert.Equal(t, "9-123", escaped.Text)
+	assert.Equal(t, "123", escaped.RawText())
+
+	upper := NewMaskedTextField(">aa|a-<aa", "")
+	upper.SetText("abCdE")
+	assert.Equal(t, "ABC-de", upper.Text)
+}
+
+func TestTextField_MaskClass(t *testing.T) {
+	tf := NewMaskedTextField("hh:hh", "")
+	tf.SetMaskClass('h', func(r rune) bool {
+		return r >= '0' && r <= '9' || r >= 'a' && r <= 'f'
+	})
+	rejected := ""
+	tf.OnInputRejected = func(s string) { rejected += s }
+	tf.SetText("a9gf0")
+	assert.Equal(t, "a9:f0", tf.Text)
+	assert.Equal(t, "g", rejected)
+}
+
+func TestTextField_MaskEditing(t *testing.T) {
+	tf := NewMaskedTextField("99/99/9999", "")
+	w := test.NewWindow(tf)
+	defer w.Close()
+	w.Canvas().Focus(tf)
+
+	test.Type(tf, "1231")
+	assert.Equal(t, "12/31", tf.Text)
+
+	// backspace over a literal removes the character before it
+	tf.CursorColumn = 3
+	tf.TypedKey(&fyne.KeyEvent{Name: fyne.KeyBackspace})
+	assert.Equal(t, "13/1", tf.Text)
+	assert.Equal(t, 1, tf.CursorColumn)
+
+	// insert in the middle shifts the following characters
+	test.Type(tf, "2")
+	assert.Equal(t, "12/31", tf.Text)
+	assert.Equal(t, 2, tf.CursorColumn)
+
+	// delete before a literal removes the character after it
+	tf.TypedKey(&fyne.KeyEvent{Name: fyne.KeyDelete})
+	assert.Equal(t, "12/1", tf.Text)
+	assert.Equal(t, 2, tf.CursorColumn)
+
+	// a rejected character does not change the text
+	rejected := ""
+	tf.OnInputRejected = func(s string) { rejected += s }
+	test.Type(tf, "x")
+	assert.Equal(t, "12/1", tf.Text)
+	assert.Equal(t, "x", rejected)
+}
@@ -0,0 +1,513 @@
+package swid
+
+import (
+	"image/color"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// MultiSelectFormField defines a form field to select several options.
+// The selected options are shown as removable chips, and they are picked
+// from a searchable dropdown.
+type MultiSelectFormField struct {
+	BaseFormField
+
+	Options     []string
+	Placeholder string
+	// Validator checks the selected options (see svalid.MinSelected and
+	// svalid.MaxSelected).
+	Validator func(selected []string) error
+
+	OnChanged func(selected []string) `json:"-"`
+	OnSaved   func(selected []string)
+
+	field        *multiSelectField
+	selected     []string
+	initialValue []string
+	popUp        *widget.PopUp
+	search       *widget.Entry
+	optionList   *fyne.Container
+	isRendered   bool // TODO remove when Fyne has a way to check if the widget has been renderered or not
+}
+
+// NewMultiSelectFormField creates a new multi select form field.
+func NewMultiSelectFormField(label string, initialValue []string, options []string) *MultiSelectFormField {
+	m := &MultiSelectFormField{}
+	m.ExtendBaseFormField(m)
+	m.Label = label
+	m.Options = options
+	m.initialValue = append([]string{}, initialValue...)
+	m.selected = append([]string{}, initialValue...)
+	m.field = newMultiSelectField(m)
+	m.field.keyHandler = &m.BaseFormField
+	m.field.updateChips()
+	return m
+}
+
+// ===============================================================
+// Methods
+// ===============================================================
+
+// Selected returns the selected options, in the order they were selected.
+func (m *MultiSelectFormField) Selected() []string {
+	return append([]string{}, m.selected...)
+}
+
+// SetSelected sets the selected options.
+func (m *MultiSelectFormField) SetSelected(selected []string) {
+	m.selected = append([]string{}, selected...)
+	m.field.updateChips()
+	m.refreshOptions()
+	m.Refresh() // refresh the whole widget
+}
+
+// IsSelected returns true if the option is selected.
+func (m *MultiSelectFormField) IsSelected(option string) bool {
+	return indexOfString(m.selected, option) >= 0
+}
+
+// Select adds the option to the selected ones.
+func (m *MultiSelectFormField) Select(option string) {
+	if m.Disabled() || m.IsSelected(option) {
+		return
+	}
+	m.selected = append(m.selected, option)
+	m.selectionChanged()
+}
+
+// Unselect removes the option from the selected ones.
+func (m *MultiSelectFormField) Unselect(option string) {
+	i := indexOfString(m.selected, option)
+	if m.Disabled() || i < 0 {
+		return
+	}
+	m.selected = append(m.selected[:i:i], m.selected[i+1:]...)
+	m.selectionChanged()
+}
+
+// Reset resets the selected options to the initial value.
+func (m *MultiSelectFormField) Reset() {
+	m.resetDirty()
+	m.SetSelected(m.initialValue)
+	m.validationError = nil
+	if m.Validator != nil {
+		m.validationError = m.Validator(m.Selected())
+	}
+	m.Refresh()
+	m.didChange()
+}
+
+// Save triggers the OnSaved callback.
+func (m *MultiSelectFormField) Save() {
+	if m.OnSaved != nil {
+		m.OnSaved(m.Selected())
+	}
+}
+
+func (m *MultiSelectFormField) draftValue() (string, bool) {
+	return strings.Join(m.selected, "\n"), true
+}
+
+func (m *MultiSelectFormField) restoreDraftValue(v string) {
+	var selected []string
+	if v != "" {
+		selected = strings.Split(v, "\n")
+	}
+	m.SetSelected(selected)
+	m.Validate()
+	m.didChange()
+}
+
+// ValidationError returns the underlying validation error.
+func (m *MultiSelectFormField) ValidationError() error {
+	if m.Validator != nil {
+		// TODO remove when Fyne has a way to check if the widget has been renderered or not
+		// means that this was called before CreateRenderer so create it by refreshing.
+		if !m.isRendered {
+			m.Refresh()
+		}
+		return m.validationError
+	}
+	return nil
+}
+
+// Validate validates the field.
+func (m *MultiSelectFormField) Validate() error {
+	m.validationPending = false
+	if m.Validator != nil {
+		err := m.Validator(m.Selected())
+		if m.validationError != err {
+			m.validationError = err
+			m.Refresh()
+		}
+		return m.validationError
+	}
+	return nil
+}
+
+// ShowOptions shows the dropdown with the options below the field.
+func (m *MultiSelectFormField) ShowOptions() {
+	if m.Disabled() {
+		return
+	}
+	c := fyne.CurrentApp().Driver().CanvasForObject(m.field)
+	if c == nil {
+		return
+	}
+	m.search = widget.NewEntry()
+	m.search.SetPlaceHolder("Search")
+	m.search.OnChanged = func(string) { m.refreshOptions() }
+	m.optionList = container.NewVBox()
+	m.refreshOptions()
+	scroll := container.NewVScroll(m.optionList)
+	scroll.SetMinSize(fyne.NewSize(m.field.Size().Width, fyne.Min(m.optionList.MinSize().Height, 200)))
+	m.popUp = widget.NewPopUp(container.NewBorder(m.search, nil, nil, nil, scroll), c)
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(m.field)
+	m.popUp.ShowAtPosition(pos.Add(fyne.NewPos(0, m.field.Size().Height)))
+	c.Focus(m.search)
+}
+
+// HideOptions hides the dropdown, if it is shown.
+func (m *MultiSelectFormField) HideOptions() {
+	if m.popUp != nil {
+		m.popUp.Hide()
+		m.popUp = nil
+	}
+}
+
+// filteredOptions returns the options that contain the query, ignoring
+// the case.
+func (m *MultiSelectFormField) filteredOptions(query string) []string {
+	query = strings.ToLower(strings.TrimSpace(query))
+	if query == "" {
+		return m.Options
+	}
+	options := make([]string, 0, len(m.Options))
+	for _, o := range m.Options {
+		if strings.Contains(strings.ToLower(o), query) {
+			options = append(options, o)
+		}
+	}
+	return options
+}
+
+// refreshOptions updates the options shown by the dropdown.
+func (m *MultiSelectFormField) refreshOptions() {
+	if m.optionList == nil {
+		return
+	}
+	options := m.filteredOptions(m.search.Text)
+	objects := make([]fyne.CanvasObject, 0, len(options))
+	for _, o := range options {
+		o := o
+		check := widget.NewCheck(o, nil)
+		check.Checked = m.IsSelected(o)
+		check.OnChanged = func(checked bool) {
+			if checked {
+				m.Select(o)
+			} else {
+				m.Unselect(o)
+			}
+		}
+		objects = append(objects, check)
+	}
+	if len(objects) == 0 {
+		objects = append(objects, widget.NewLabel("No results"))
+	}
+	m.optionList.Objects = objects
+	m.optionList.Refresh()
+}
+
+func (m *MultiSelectFormField) selectionChanged() {
+	m.dirty = true
+	m.field.updateChips()
+	m.refreshOptions()
+	if m.Validator != nil && m.shouldRunValidator() {
+		m.validationError = m.Validator(m.Selected())
+	}
+	if m.OnChanged != nil {
+		m.OnChanged(m.Selected())
+	}
+	m.didChange()
+	m.Refresh()
+}
+
+func (m *MultiSelectFormField) focusChanged(focused bool) {
+	if !focused {
+		m.blurred = true
+		if old := m.validationError; m.validateOnBlur() && m.Validate() != old {
+			// notify the form about the validation change.
+			m.didChange()
+		}
+	}
+	m.Refresh()
+}
+
+func (m *MultiSelectFormField) focusTarget() fyne.Focusable {
+	return m.field
+}
+
+// ===============================================================
+// Renderer
+// ===============================================================
+
+// CreateRenderer implements fyne.Widget.
+func (m *MultiSelectFormField) CreateRenderer() fyne.WidgetRenderer {
+	m.ExtendBaseFormField(m)
+
+	if m.Validator != nil {
+		m.validationError = m.Validator(m.Selected())
+	}
+
+	isFieldEmpty := func() bool {
+		return len(m.selected) == 0
+	}
+
+	isFieldFocused := func() bool {
+		return m.field.focused
+	}
+
+	updateInternalField := func() {
+		for _, c := range m.field.chips {
+			c.(*chip).setRemovable(!m.Disabled())
+		}
+		m.field.Refresh()
+	}
+
+	r := m.CreateBaseRenderer(
+		m.Label, m.Hint, m.field,
+		isFieldEmpty, isFieldFocused,
+		updateInternalField,
+	)
+
+	r.(*formFieldRenderer).labelBgColor = func() color.Color {
+		if m.field.hovered && !m.Disabled() {
+			return theme.HoverColor()
+		}
+		return theme.InputBackgroundColor()
+	}
+
+	m.isRendered = true // TODO remove when Fyne has a way to check if the widget has been renderered or not
+
+	return r
+}
+
+// ===============================================================
+// Multi select field
+// ===============================================================
+
+// multiSelectField is the internal widget of a MultiSelectFormField. It
+// shows the selected options as chips and a button to open the dropdown.
+type multiSelectField struct {
+	widget.BaseWidget
+	field *MultiSelectFormField
+
+	chips          []fyne.CanvasObject
+	dropDownButton *adornmentButton
+
+	hovered bool
+	focused bool
+
+	fieldKeys
+}
+
+func newMultiSelectField(field *MultiSelectFormField) *multiSelectField {
+	s := &multiSelectField{field: field}
+	s.ExtendBaseWidget(s)
+	s.dropDownButton = newAdornmentButton(theme.MenuDropDownIcon(), s.showOptions)
+	return s
+}
+
+// updateChips creates the chips of the selected options.
+func (s *multiSelectField) updateChips() {
+	chips := make([]fyne.CanvasObject, 0, len(s.field.selected))
+	for _, o := range s.field.selected {
+		o := o
+		c := newChip(o, func() { s.field.Unselect(o) })
+		c.removable = !s.field.Disabled()
+		chips = append(chips, c)
+	}
+	s.chips = chips
+}
+
+func (s *multiSelectField) showOptions() {
+	if c := fyne.CurrentApp().Driver().CanvasForObject(s); c != nil && !s.field.Disabled() {
+		c.Focus(s)
+	}
+	s.field.ShowOptions()
+}
+
+// Cursor implements desktop.Cursorable.
+func (s *multiSelectField) Cursor() desktop.Cursor {
+	return desktop.DefaultCursor
+}
+
+// MouseIn implements desktop.Hoverable.
+func (s *multiSelectField) MouseIn(*desktop.MouseEvent) {
+	s.hovered = true
+	s.field.Refresh()
+}
+
+// MouseMoved implements desktop.Hoverable.
+func (s *multiSelectField) MouseMoved(*desktop.MouseEvent) {}
+
+// MouseOut implements desktop.Hoverable.
+func (s *multiSelectField) MouseOut() {
+	s.hovered = false
+	s.field.Refresh()
+}
+
+// Tapped implements fyne.Tappable.
+func (s *multiSelectField) Tapped(*fyne.PointEvent) {
+	s.showOptions()
+}
+
+// Disable implements fyne.Disableable.
+func (s *multiSelectField) Disable() {
+	s.field.Disable()
+}
+
+// Enable implements fyne.Disableable.
+func (s *multiSelectField) Enable() {
+	s.field.Enable()
+}
+
+// Disabled implements fyne.Disableable.
+func (s *multiSelectField) Disabled() bool {
+	return s.field.Disabled()
+}
+
+// FocusGained implements fyne.Focusable.
+func (s *multiSelectField) FocusGained() {
+	s.focused = true
+	s.field.focusChanged(true)
+}
+
+// FocusLost implements fyne.Focusable.
+func (s *multiSelectField) FocusLost() {
+	s.focused = false
+	s.field.focusChanged(false)
+}
+
+// TypedRune implements fyne.Focusable.
+func (s *multiSelectField) TypedRune(r rune) {
+	if r == ' ' {
+		s.field.ShowOptions()
+	}
+}
+
+// TypedKey implements fyne.Focusable.
+func (s *multiSelectField) TypedKey(key *fyne.KeyEvent) {
+	if s.typedNavigationKey(key.Name) {
+		return
+	}
+	switch key.Name {
+	case fyne.KeyDown:
+		s.field.ShowOptions()
+	case fyne.KeyBackspace:
+		if n := len(s.field.selected); n > 0 {
+			s.field.Unselect(s.field.selected[n-1])
+		}
+	}
+}
+
+func (s *multiSelectField) CreateRenderer() fyne.WidgetRenderer {
+	s.ExtendBaseWidget(s)
+	r := &multiSelectFieldRenderer{
+		line:        canvas.NewRectangle(theme.ShadowColor()),
+		placeholder: canvas.NewText("", theme.PlaceHolderColor()),
+		widget:      s,
+	}
+	r.Refresh()
+	return r
+}
+
+type multiSelectFieldRenderer struct {
+	line        *canvas.Rectangle
+	placeholder *canvas.Text
+	widget      *multiSelectField
+	objects     []fyne.CanvasObject
+}
+
+func (r *multiSelectFieldRenderer) Destroy() {}
+
+func (r *multiSelectFieldRenderer) Layout(size fyne.Size) {
+	pad := theme.Padding()
+	r.line.Move(fyne.NewPos(0, size.Height-theme.InputBorderSize()))
+	r.line.Resize(fyne.NewSize(size.Width, theme.InputBorderSize()))
+
+	iconSize := theme.IconInlineSize()
+	r.widget.dropDownButton.Move(fyne.NewPos(size.Width-pad*2-iconSize, (size.Height-iconSize)/2))
+	r.widget.dropDownButton.Resize(fyne.NewSize(iconSize, iconSize))
+
+	chipsWidth := r.chipsWidth(size.Width)
+	chipsHeight := chipsHeight(r.widget.chips, chipsWidth)
+	layoutChips(r.widget.chips, fyne.NewPos(pad*2, (size.Height-chipsHeight)/2), chipsWidth)
+
+	placeholderMin := r.placeholder.MinSize()
+	r.placeholder.Move(fyne.NewPos(pad*2, (size.Height-placeholderMin.Height)/2))
+	r.placeholder.Resize(fyne.NewSize(chipsWidth, placeholderMin.Height))
+}
+
+// chipsWidth returns the width available for the chips.
+func (r *multiSelectFieldRenderer) chipsWidth(width float32) float32 {
+	return width - theme.Padding()*5 - theme.IconInlineSize()
+}
+
+func (r *multiSelectFieldRenderer) MinSize() fyne.Size {
+	pad := theme.Padding()
+	textHeight := fyne.MeasureText("M", theme.TextSize(), fyne.TextStyle{}).Height
+	min := fyne.NewSize(pad*5+theme.IconInlineSize()+r.placeholder.MinSize().Width, textHeight+pad*4)
+	if width := r.widget.Size().Width; width > 0 && len(r.widget.chips) > 0 {
+		// the chips wrap at the current width.
+		min.Height = fyne.Max(min.Height, chipsHeight(r.widget.chips, r.chipsWidth(width))+pad*2)
+	}
+	return min
+}
+
+func (r *multiSelectFieldRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *multiSelectFieldRenderer) Refresh() {
+	s := r.widget
+	r.line.FillColor = theme.ShadowColor()
+	if s.focused && !s.Disabled() {
+		r.line.FillColor = theme.PrimaryColor()
+	} else if s.field.isErrorVisible(false) {
+		r.line.FillColor = theme.ErrorColor()
+	}
+	r.placeholder.Text = s.field.Placeholder
+	r.placeholder.TextSize = theme.TextSize()
+	r.placeholder.Color = theme.PlaceHolderColor()
+	r.placeholder.Hidden = len(s.chips) > 0 || !s.focused
+
+	r.objects = []fyne.CanvasObject{r.line, r.placeholder}
+	r.objects = append(r.objects, s.chips...)
+	if !s.Disabled() {
+		r.objects = append(r.objects, s.dropDownButton)
+	}
+	r.line.Refresh()
+	r.placeholder.Refresh()
+	r.Layout(s.Size())
+	canvas.Refresh(s)
+}
+
+// ===============================================================
+// Private helpers
+// ===============================================================
+
+func indexOfString(values []string, v string) int {
+	for i, s := range values {
+		if s == v {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,87 @@
+package swid
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/fpabl0/sparky-go/svalid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiSelectFormField(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	m := NewMultiSelectFormField("Languages", []string{"Go"}, []string{"Go", "Rust", "Python", "TypeScript"})
+	m.Validator = func(selected []string) error {
+		if err := svalid.MinSelected(2)(selected); err != nil {
+			return err
+		}
+		return svalid.MaxSelected(3)(selected)
+	}
+	var saved []string
+	m.OnSaved = func(selected []string) { saved = selected }
+
+	f := NewForm(1, m)
+	w := test.NewWindow(f)
+	w.Resize(fyne.NewSize(300, 200))
+	defer w.Close()
+
+	assert.Equal(t, []string{"Go"}, m.Selected())
+	assert.Error(t, m.ValidationError())
+	assert.False(t, f.IsValid())
+
+	m.Select("Rust")
+	assert.Equal(t, []string{"Go", "Rust"}, m.Selected())
+	assert.Len(t, m.field.chips, 2)
+	assert.True(t, f.IsValid())
+
+	m.Select("Python")
+	m.Select("TypeScript")
+	assert.Error(t, m.ValidationError())
+
+	test.Tap(m.field.chips[0].(*chip).removeButton)
+	assert.Equal(t, []string{"Rust", "Python", "TypeScript"}, m.Selected())
+	assert.NoError(t, m.ValidationError())
+
+	m.Save()
+	assert.Equal(t, []string{"Rust", "Python", "TypeScript"}, saved)
+
+	m.Reset()
+	assert.Equal(t, []string{"Go"}, m.Selected())
+	assert.Len(t, m.field.chips, 1)
+}
+
+func TestMultiSelectFormField_Search(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	m := NewMultiSelectFormField("Languages", nil, []string{"Go", "Rust", "Python", "TypeScript"})
+	w := test.NewWindow(m)
+	w.Resize(fyne.NewSize(300, 200))
+	defer w.Close()
+
+	test.Tap(m.field)
+	assert.NotNil(t, m.popUp)
+	assert.Len(t, m.optionList.Objects, 4)
+
+	test.Type(m.search, "t")
+	assert.Len(t, m.optionList.Objects, 3)
+	test.Type(m.search, "y")
+	assert.Len(t, m.optionList.Objects, 1)
+	check := m.optionList.Objects[0].(*widget.Check)
+	assert.Equal(t, "TypeScript", check.Text)
+	test.Tap(check)
+	assert.Equal(t, []string{"TypeScript"}, m.Selected())
+
+	test.Type(m.search, "z")
+	assert.Equal(t, "No results", m.optionList.Objects[0].(*widget.Label).Text)
+	m.HideOptions()
+	assert.Nil(t, m.popUp)
+
+	m.field.TypedKey(&fyne.KeyEvent{Name: fyne.KeyBackspace})
+	assert.Empty(t, m.Selected())
+
+	m.Disable()
+	m.Select("Go")
+	assert.Empty(t, m.Selected())
+}
@@ -0,0 +1,335 @@
+package swid
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+	"github.com/fpabl0/sparky-go/svalid"
+)
+
+// NumberFormat defines how the value of a NumberFormField is shown.
+type NumberFormat struct {
+	// DecimalSeparator defaults to '.'.
+	DecimalSeparator rune
+	// ThousandsSeparator is not used if it is 0.
+	ThousandsSeparator rune
+	// Decimals is the number of decimals shown when the field loses the
+	// focus. If it is negative, the decimals typed by the user are kept.
+	Decimals int
+	// Prefix and Suffix are shown before and after the value (like "$"
+	// or "%"), they are not part of the text.
+	Prefix string
+	Suffix string
+}
+
+// NumberFormField defines a special text form field for numbers. The
+// number is formatted with its NumberFormat when the field loses the focus.
+// The Validator receives the value without the format (like "-1234.5"), so
+// it can be used with the svalid number validators.
+type NumberFormField struct {
+	TextFormField
+
+	// Min and Max define the allowed range. It is only checked if Max is
+	// greater than Min.
+	Min float64
+	Max float64
+	// Step is the value added or subtracted by the spinner buttons and the
+	// up and down keys.
+	Step float64
+
+	isInt        bool
+	format       NumberFormat
+	upButton     *adornmentButton
+	downButton   *adornmentButton
+	prefixObject fyne.CanvasObject
+	suffixObject fyne.CanvasObject
+}
+
+// NewIntFormField creates a new number form field for integers.
+func NewIntFormField(label string, initialValue int) *NumberFormField {
+	n := newNumberFormField(label, true, NumberFormat{Decimals: 0})
+	n.setInitialValue(float64(initialValue))
+	return n
+}
+
+// NewFloatFormField creates a new number form field for floats. The value
+// is shown with the specified number of decimals (use -1 to keep the
+// decimals typed by the user).
+func NewFloatFormField(label string, initialValue float64, decimals int) *NumberFormField {
+	n := newNumberFormField(label, false, NumberFormat{Decimals: decimals})
+	n.setInitialValue(initialValue)
+	return n
+}
+
+func newNumberFormField(label string, isInt bool, format NumberFormat) *NumberFormField {
+	n := &NumberFormField{Step: 1, isInt: isInt, format: format}
+	n.ExtendBaseFormField(n)
+	n.Label = label
+	n.Wrapping = fyne.TextTruncate
+	n.setupTextField()
+	n.textField.acceptRune = n.acceptRune
+	n.textField.onTypedKey = n.typedKey
+	n.valueValidator = n.validateText
+	n.upButton = newAdornmentButton(theme.MoveUpIcon(), n.Increment)
+	n.downButton = newAdornmentButton(theme.MoveDownIcon(), n.Decrement)
+	n.extraTrailing = []fyne.CanvasObject{n.downButton, n.upButton}
+
+	onFocusChanged := n.textField.onFocusChanged
+	n.textField.onFocusChanged = func(focused bool) {
+		if !focused {
+			n.formatText()
+		}
+		onFocusChanged(focused)
+	}
+	return n
+}
+
+// ===============================================================
+// Methods
+// ===============================================================
+
+// Float returns the current value. It returns 0 if the text is empty or it
+// is not a valid number.
+func (n *NumberFormField) Float() float64 {
+	v, err := n.parse(n.textField.Text)
+	if err != nil || v == nil {
+		return 0
+	}
+	return *v
+}
+
+// Int returns the current value rounded to the nearest integer.
+func (n *NumberFormField) Int() int {
+	return int(math.Round(n.Float()))
+}
+
+// IsEmpty returns true if the field has no value.
+func (n *NumberFormField) IsEmpty() bool {
+	return strings.TrimSpace(n.textField.Text) == ""
+}
+
+// SetValue sets the value, formatted with the field NumberFormat.
+func (n *NumberFormField) SetValue(v float64) {
+	n.SetText(n.formatValue(v))
+}
+
+// SetFormat sets the format used to show the value.
+func (n *NumberFormField) SetFormat(format NumberFormat) {
+	v, err := n.parse(n.textField.Text)
+	initial, initialErr := n.parse(n.initialText)
+	n.format = format
+	if n.isInt {
+		n.format.Decimals = 0
+	}
+	n.updateAdornments()
+	if initialErr == nil && initial != nil {
+		n.initialText = n.formatValue(*initial)
+	}
+	if err == nil && v != nil {
+		n.SetValue(*v)
+	}
+}
+
+// Format returns the format used to show the value.
+func (n *NumberFormField) Format() NumberFormat {
+	return n.format
+}
+
+// Increment adds Step to the value, limited to Max.
+func (n *NumberFormField) Increment() {
+	n.stepBy(n.step())
+}
+
+// Decrement subtracts Step from the value, limited to Min.
+func (n *NumberFormField) Decrement() {
+	n.stepBy(-n.step())
+}
+
+func (n *NumberFormField) step() float64 {
+	if n.Step <= 0 {
+		return 1
+	}
+	return n.Step
+}
+
+func (n *NumberFormField) stepBy(delta float64) {
+	if n.Disabled() {
+		return
+	}
+	v := n.Float()
+	if n.IsEmpty() && n.hasRange() {
+		v = n.Min - delta
+	}
+	v += delta
+	if n.hasRange() {
+		v = math.Max(n.Min, math.Min(n.Max, v))
+	}
+	n.SetValue(v)
+}
+
+func (n *NumberFormField) hasRange() bool {
+	return n.Max > n.Min
+}
+
+func (n *NumberFormField) setInitialValue(v float64) {
+	n.initialText = n.formatValue(v)
+	n.textField.Text = n.initialText
+}
+
+// validateText checks the text is a valid number inside the range, and
+// then runs the Validator with the value without the format.
+func (n *NumberFormField) validateText(text string) error {
+	v, err := n.parse(text)
+	if err != nil {
+		return err
+	}
+	value := ""
+	if v != nil {
+		value = strconv.FormatFloat(*v, 'f', -1, 64)
+		if n.hasRange() {
+			if err := svalid.Range(n.Min, n.Max)(value); err != nil {
+				return err
+			}
+		}
+	}
+	if n.Validator != nil {
+		return n.Validator(value)
+	}
+	return nil
+}
+
+func (n *NumberFormField) acceptRune(text string, r rune, pos int) bool {
+	switch {
+	case unicode.IsDigit(r):
+		return !strings.HasPrefix(text, "-") || pos > 0
+	case r == '-':
+		return pos == 0 && !strings.HasPrefix(text, "-") && (!n.hasRange() || n.Min < 0)
+	case r == n.decimalSeparator():
+		return !n.isInt && !strings.ContainsRune(text, r)
+	case r == n.format.ThousandsSeparator && r != 0:
+		return true
+	}
+	return false
+}
+
+func (n *NumberFormField) typedKey(key *fyne.KeyEvent) bool {
+	if n.textField.MultiLine || n.Disabled() {
+		return false
+	}
+	switch key.Name {
+	case fyne.KeyUp:
+		n.Increment()
+		return true
+	case fyne.KeyDown:
+		n.Decrement()
+		return true
+	}
+	return false
+}
+
+func (n *NumberFormField) decimalSeparator() rune {
+	if n.format.DecimalSeparator == 0 {
+		return '.'
+	}
+	return n.format.DecimalSeparator
+}
+
+// parse returns the value of the text, or nil if it is empty.
+func (n *NumberFormField) parse(text string) (*float64, error) {
+	return parseNumber(text, n.format)
+}
+
+// formatText formats the current text, if it is a valid number.
+func (n *NumberFormField) formatText() {
+	v, err := n.parse(n.textField.Text)
+	if err != nil || v == nil {
+		return
+	}
+	if text := n.formatValue(*v); text != n.textField.Text {
+		n.SetText(text)
+	}
+}
+
+func (n *NumberFormField) formatValue(v float64) string {
+	return formatNumber(v, n.format)
+}
+
+func (n *NumberFormField) updateAdornments() {
+	if n.Leading == n.prefixObject {
+		n.Leading = nil
+	}
+	if n.Trailing == n.suffixObject {
+		n.Trailing = nil
+	}
+	n.prefixObject, n.suffixObject = nil, nil
+	if n.format.Prefix != "" && n.Leading == nil {
+		n.prefixObject = NewTextAdornment(n.format.Prefix)
+		n.Leading = n.prefixObject
+	}
+	if n.format.Suffix != "" && n.Trailing == nil {
+		n.suffixObject = NewTextAdornment(n.format.Suffix)
+		n.Trailing = n.suffixObject
+	}
+	n.Refresh()
+}
+
+// ===============================================================
+// Private helpers
+// ===============================================================
+
+// parseNumber parses a number formatted with the format. It returns nil
+// if the text is empty.
+func parseNumber(text string, format NumberFormat) (*float64, error) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return nil, nil
+	}
+	if format.ThousandsSeparator != 0 {
+		text = strings.ReplaceAll(text, string(format.ThousandsSeparator), "")
+	}
+	if format.DecimalSeparator != 0 && format.DecimalSeparator != '.' {
+		text = strings.ReplaceAll(text, string(format.DecimalSeparator), ".")
+	}
+	if err := svalid.Number()(text); err != nil {
+		return nil, err
+	}
+	v, _ := strconv.ParseFloat(text, 64)
+	return &v, nil
+}
+
+// formatNumber formats the value with the decimal and thousands separators
+// of the format.
+func formatNumber(v float64, format NumberFormat) string {
+	sign := ""
+	if v < 0 {
+		sign = "-"
+		v = -v
+	}
+	s := strconv.FormatFloat(v, 'f', format.Decimals, 64)
+	intPart, fracPart := s, ""
+	if i := strings.IndexByte(s, '.'); i >= 0 {
+		intPart, fracPart = s[:i], s[i+1:]
+	}
+	if format.ThousandsSeparator != 0 && len(intPart) > 3 {
+		var b strings.Builder
+		for i, r := range intPart {
+			if i > 0 && (len(intPart)-i)%3 == 0 {
+				b.WriteRune(format.ThousandsSeparator)
+			}
+			b.WriteRune(r)
+		}
+		intPart = b.String()
+	}
+	if fracPart == "" {
+		return sign + intPart
+	}
+	sep := format.DecimalSeparator
+	if sep == 0 {
+		sep = '.'
+	}
+	return sign + intPart + string(sep) + fracPart
+}
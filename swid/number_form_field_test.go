@@ -0,0 +1,83 @@
+package swid
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"github.com/fpabl0/sparky-go/svalid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNumberFormField_Int(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	n := NewIntFormField("Age", 18)
+	n.Min, n.Max = 0, 120
+	assert.Equal(t, "18", n.Text())
+	assert.Equal(t, 18, n.Int())
+
+	w := test.NewWindow(n)
+	defer w.Close()
+
+	w.Canvas().Focus(n.textField)
+	n.textField.CursorColumn = 2
+	test.Type(n.textField, "a.-5")
+	assert.Equal(t, "185", n.Text())
+	assert.Error(t, n.Validate())
+
+	n.SetValue(120)
+	assert.NoError(t, n.Validate())
+	n.Increment()
+	assert.Equal(t, 120, n.Int())
+
+	n.textField.TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	assert.Equal(t, 119, n.Int())
+	n.Step = 10
+	test.Tap(n.downButton)
+	assert.Equal(t, 109, n.Int())
+	test.Tap(n.upButton)
+	assert.Equal(t, 119, n.Int())
+
+	n.SetText("")
+	n.Increment()
+	assert.Equal(t, 0, n.Int())
+}
+
+func TestNumberFormField_Format(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	n := NewFloatFormField("Price", 1234.5, 2)
+	n.SetFormat(NumberFormat{
+		DecimalSeparator:   ',',
+		ThousandsSeparator: '.',
+		Decimals:           2,
+		Prefix:             "€",
+	})
+	assert.Equal(t, "1.234,50", n.Text())
+	assert.Equal(t, 1234.5, n.Float())
+	assert.NotNil(t, n.Leading)
+
+	values := []string{}
+	n.Validator = func(s string) error {
+		values = append(values, s)
+		return svalid.Max(5000)(s)
+	}
+	other := NewTextFormField("Other", "")
+	w := test.NewWindow(NewForm(1, n, other))
+	defer w.Close()
+
+	w.Canvas().Focus(n.textField)
+	n.SetText("")
+	test.Type(n.textField, "9876,5")
+	assert.Equal(t, "9876,5", n.Text())
+	assert.Error(t, n.Validate())
+	assert.Equal(t, "9876.5", values[len(values)-1])
+
+	// the value is formatted when the field loses the focus
+	w.Canvas().Focus(other.textField)
+	assert.Equal(t, "9.876,50", n.Text())
+	assert.Equal(t, 9876.5, n.Float())
+
+	n.Reset()
+	assert.Equal(t, "1.234,50", n.Text())
+}
@@ -0,0 +1,162 @@
+package swid
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+)
+
+// RadioGroupFormField defines a special radio group field for Forms.
+type RadioGroupFormField struct {
+	BaseFormField
+
+	Options    []string
+	Horizontal bool
+	Validator  fyne.StringValidator
+
+	OnChanged func(string) `json:"-"`
+	OnSaved   func(s string)
+
+	radioGroup   *widget.RadioGroup
+	initialValue string
+	isRendered   bool // TODO remove when Fyne has a way to check if the widget has been renderered or not
+}
+
+// NewRadioGroupFormField creates a new radio group form field.
+func NewRadioGroupFormField(label, initialValue string, options []string) *RadioGroupFormField {
+	r := &RadioGroupFormField{}
+	r.ExtendBaseFormField(r)
+	r.Label = label
+	r.Options = options
+	r.initialValue = initialValue
+	r.setupRadioGroup()
+	return r
+}
+
+// ===============================================================
+// Methods
+// ===============================================================
+
+// Selected returns the selected option.
+func (r *RadioGroupFormField) Selected() string {
+	return r.radioGroup.Selected
+}
+
+// SetSelected sets the selected option.
+func (r *RadioGroupFormField) SetSelected(option string) {
+	r.radioGroup.Selected = option
+	r.Refresh() // refresh the whole widget
+}
+
+// Reset resets the selected option to the initial value.
+func (r *RadioGroupFormField) Reset() {
+	r.resetDirty()
+	r.radioGroup.Selected = r.initialValue
+	r.validationError = nil
+	if r.Validator != nil {
+		r.validationError = r.Validator(r.radioGroup.Selected)
+	}
+	r.Refresh()
+	r.didChange()
+}
+
+// Save triggers the OnSaved callback.
+func (r *RadioGroupFormField) Save() {
+	if r.OnSaved != nil {
+		r.OnSaved(r.radioGroup.Selected)
+	}
+}
+
+func (r *RadioGroupFormField) draftValue() (string, bool) {
+	return r.radioGroup.Selected, true
+}
+
+func (r *RadioGroupFormField) restoreDraftValue(v string) {
+	r.SetSelected(v)
+	r.Validate()
+	r.didChange()
+}
+
+// ValidationError returns the underlying validation error.
+func (r *RadioGroupFormField) ValidationError() error {
+	if r.Validator != nil {
+		// TODO remove when Fyne has a way to check if the widget has been renderered or not
+		// means that this was called before CreateRenderer so create it by refreshing.
+		if !r.isRendered {
+			r.Refresh()
+		}
+		return r.validationError
+	}
+	return nil
+}
+
+// Validate validates the field.
+func (r *RadioGroupFormField) Validate() error {
+	r.validationPending = false
+	if r.Validator != nil {
+		err := r.Validator(r.radioGroup.Selected)
+		if r.validationError != err {
+			r.validationError = err
+			r.Refresh()
+		}
+		return r.validationError
+	}
+	return nil
+}
+
+func (r *RadioGroupFormField) setupRadioGroup() {
+	r.radioGroup = widget.NewRadioGroup(r.Options, nil)
+	r.radioGroup.Selected = r.initialValue
+	r.radioGroup.OnChanged = func(option string) {
+		r.dirty = true
+		if r.Validator != nil && r.shouldRunValidator() {
+			r.validationError = r.Validator(option)
+		}
+		if r.OnChanged != nil {
+			r.OnChanged(option)
+		}
+		r.didChange()
+		r.Refresh()
+	}
+}
+
+// ===============================================================
+// Renderer
+// ===============================================================
+
+// CreateRenderer implements fyne.Widget.
+func (r *RadioGroupFormField) CreateRenderer() fyne.WidgetRenderer {
+	r.ExtendBaseFormField(r)
+
+	if r.Validator != nil {
+		r.validationError = r.Validator(r.radioGroup.Selected)
+	}
+
+	isFieldEmpty := func() bool {
+		return false // the label is always stacked above the options.
+	}
+
+	isFieldFocused := func() bool {
+		return false // the radio items are focused, not the group.
+	}
+
+	updateInternalField := func() {
+		r.radioGroup.Options = r.Options
+		r.radioGroup.Horizontal = r.Horizontal
+		if r.Disabled() {
+			r.radioGroup.Disable()
+		} else {
+			r.radioGroup.Enable()
+		}
+		r.radioGroup.Refresh()
+	}
+
+	rr := r.createPristineRenderer(
+		r.Label, r.Hint, r.radioGroup,
+		isFieldEmpty, isFieldFocused,
+		updateInternalField,
+	)
+
+	r.isRendered = true // TODO remove when Fyne has a way to check if the widget has been renderered or not
+
+	return rr
+}
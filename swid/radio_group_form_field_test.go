@@ -0,0 +1,43 @@
+package swid
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"github.com/fpabl0/sparky-go/svalid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRadioGroupFormField(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	r := NewRadioGroupFormField("Size", "", []string{"S", "M", "L"})
+	r.Validator = svalid.NotEmpty()
+	r.Horizontal = true
+
+	saved := ""
+	r.OnSaved = func(s string) { saved = s }
+
+	f := NewForm(1, r)
+	w := test.NewWindow(f)
+	defer w.Close()
+
+	assert.Error(t, r.ValidationError())
+	assert.True(t, r.radioGroup.Horizontal)
+
+	r.radioGroup.SetSelected("M")
+	assert.Equal(t, "M", r.Selected())
+	assert.NoError(t, r.ValidationError())
+	assert.True(t, f.IsValid())
+
+	r.Save()
+	assert.Equal(t, "M", saved)
+
+	r.Reset()
+	assert.Equal(t, "", r.Selected())
+	assert.Error(t, r.ValidationError())
+	assert.False(t, f.IsValid())
+
+	r.SetSelected("L")
+	assert.NoError(t, r.Validate())
+}
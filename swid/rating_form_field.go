@@ -0,0 +1,427 @@
+package swid
+
+import (
+	"math"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+const defaultRatingStars = 5
+
+// RatingFormField defines a form field to give a rating with stars.
+type RatingFormField struct {
+	BaseFormField
+
+	// Stars is the number of stars, 5 by default.
+	Stars int
+	// AllowHalf allows half stars, like 3.5.
+	AllowHalf bool
+	Validator func(rating float64) error
+
+	OnChanged func(rating float64) `json:"-"`
+	OnSaved   func(rating float64)
+
+	field         *ratingField
+	rating        float64
+	initialRating float64
+	isRendered    bool // TODO remove when Fyne has a way to check if the widget has been renderered or not
+}
+
+// NewRatingFormField creates a new rating form field with the number of
+// stars.
+func NewRatingFormField(label string, stars int, initialRating float64) *RatingFormField {
+	r := &RatingFormField{Stars: stars}
+	r.ExtendBaseFormField(r)
+	r.Label = label
+	r.initialRating = initialRating
+	r.rating = r.clamp(initialRating)
+	r.field = newRatingField(r)
+	r.field.keyHandler = &r.BaseFormField
+	return r
+}
+
+// ===============================================================
+// Methods
+// ===============================================================
+
+// Rating returns the rating, 0 if there is no rating.
+func (r *RatingFormField) Rating() float64 {
+	return r.rating
+}
+
+// SetRating sets the rating. It is rounded to the stars (or half stars if
+// allowed).
+func (r *RatingFormField) SetRating(rating float64) {
+	r.rating = r.clamp(rating)
+	r.Refresh() // refresh the whole widget
+}
+
+// Reset resets the field to the initial rating.
+func (r *RatingFormField) Reset() {
+	r.resetDirty()
+	r.SetRating(r.initialRating)
+	r.validationError = nil
+	if r.Validator != nil {
+		r.validationError = r.Validator(r.rating)
+	}
+	r.Refresh()
+	r.didChange()
+}
+
+// Save triggers the OnSaved callback.
+func (r *RatingFormField) Save() {
+	if r.OnSaved != nil {
+		r.OnSaved(r.rating)
+	}
+}
+
+func (r *RatingFormField) draftValue() (string, bool) {
+	return strconv.FormatFloat(r.rating, 'f', -1, 64), true
+}
+
+func (r *RatingFormField) restoreDraftValue(v string) {
+	if rating, err := strconv.ParseFloat(v, 64); err == nil {
+		r.SetRating(rating)
+		r.Validate()
+		r.didChange()
+	}
+}
+
+// ValidationError returns the underlying validation error.
+func (r *RatingFormField) ValidationError() error {
+	if r.Validator != nil {
+		// TODO remove when Fyne has a way to check if the widget has been renderered or not
+		// means that this was called before CreateRenderer so create it by refreshing.
+		if !r.isRendered {
+			r.Refresh()
+		}
+		return r.validationError
+	}
+	return nil
+}
+
+// Validate validates the field.
+func (r *RatingFormField) Validate() error {
+	r.validationPending = false
+	if r.Validator != nil {
+		err := r.Validator(r.rating)
+		if r.validationError != err {
+			r.validationError = err
+			r.Refresh()
+		}
+		return r.validationError
+	}
+	return nil
+}
+
+// rate sets the rating chosen by the user.
+func (r *RatingFormField) rate(rating float64) {
+	if r.Disabled() {
+		return
+	}
+	rating = r.clamp(rating)
+	if rating == r.rating {
+		return
+	}
+	r.rating = rating
+	r.dirty = true
+	if r.Validator != nil && r.shouldRunValidator() {
+		r.validationError = r.Validator(r.rating)
+	}
+	if r.OnChanged != nil {
+		r.OnChanged(r.rating)
+	}
+	r.didChange()
+	r.Refresh()
+}
+
+// step returns the smallest change of the rating.
+func (r *RatingFormField) step() float64 {
+	if r.AllowHalf {
+		return 0.5
+	}
+	return 1
+}
+
+func (r *RatingFormField) stars() int {
+	if r.Stars <= 0 {
+		return defaultRatingStars
+	}
+	return r.Stars
+}
+
+func (r *RatingFormField) clamp(rating float64) float64 {
+	rating = math.Round(rating/r.step()) * r.step()
+	return math.Max(0, math.Min(float64(r.stars()), rating))
+}
+
+func (r *RatingFormField) focusChanged(focused bool) {
+	if !focused {
+		r.blurred = true
+		if old := r.validationError; r.validateOnBlur() && r.Validate() != old {
+			// notify the form about the validation change.
+			r.didChange()
+		}
+	}
+	r.Refresh()
+}
+
+func (r *RatingFormField) focusTarget() fyne.Focusable {
+	return r.field
+}
+
+// ===============================================================
+// Renderer
+// ===============================================================
+
+// CreateRenderer implements fyne.Widget.
+func (r *RatingFormField) CreateRenderer() fyne.WidgetRenderer {
+	r.ExtendBaseFormField(r)
+
+	if r.Validator != nil {
+		r.validationError = r.Validator(r.rating)
+	}
+
+	isFieldEmpty := func() bool {
+		return false // the label is always stacked above the stars.
+	}
+
+	isFieldFocused := func() bool {
+		return r.field.focused
+	}
+
+	updateInternalField := func() {
+		r.field.Refresh()
+	}
+
+	rr := r.createPristineRenderer(
+		r.Label, r.Hint, r.field,
+		isFieldEmpty, isFieldFocused,
+		updateInternalField,
+	)
+
+	r.isRendered = true // TODO remove when Fyne has a way to check if the widget has been renderered or not
+
+	return rr
+}
+
+// ===============================================================
+// Rating field
+// ===============================================================
+
+// ratingField is the internal widget of a RatingFormField. It shows the
+// stars.
+type ratingField struct {
+	widget.BaseWidget
+	field *RatingFormField
+
+	hovered bool
+	focused bool
+
+	fieldKeys
+}
+
+func newRatingField(field *RatingFormField) *ratingField {
+	r := &ratingField{field: field}
+	r.ExtendBaseWidget(r)
+	return r
+}
+
+// ratingAt returns the rating for a tap at the x position.
+func (r *ratingField) ratingAt(x float32) float64 {
+	pad := theme.Padding()
+	size := ratingStarSize()
+	x -= pad * 2
+	if x < 0 {
+		return 0
+	}
+	star := int(x / (size + pad))
+	offset := x - float32(star)*(size+pad)
+	rating := float64(star) + 1
+	if r.field.AllowHalf && offset < size/2 {
+		rating -= 0.5
+	}
+	return rating
+}
+
+// Cursor implements desktop.Cursorable.
+func (r *ratingField) Cursor() desktop.Cursor {
+	return desktop.DefaultCursor
+}
+
+// MouseIn implements desktop.Hoverable.
+func (r *ratingField) MouseIn(*desktop.MouseEvent) {
+	r.hovered = true
+	r.Refresh()
+}
+
+// MouseMoved implements desktop.Hoverable.
+func (r *ratingField) MouseMoved(*desktop.MouseEvent) {}
+
+// MouseOut implements desktop.Hoverable.
+func (r *ratingField) MouseOut() {
+	r.hovered = false
+	r.Refresh()
+}
+
+// Tapped implements fyne.Tappable. Tapping the current rating clears it.
+func (r *ratingField) Tapped(ev *fyne.PointEvent) {
+	if r.field.Disabled() {
+		return
+	}
+	if c := fyne.CurrentApp().Driver().CanvasForObject(r); c != nil {
+		c.Focus(r)
+	}
+	rating := r.ratingAt(ev.Position.X)
+	if rating == r.field.rating {
+		rating = 0
+	}
+	r.field.rate(rating)
+}
+
+// FocusGained implements fyne.Focusable.
+func (r *ratingField) FocusGained() {
+	r.focused = true
+	r.field.focusChanged(true)
+}
+
+// FocusLost implements fyne.Focusable.
+func (r *ratingField) FocusLost() {
+	r.focused = false
+	r.field.focusChanged(false)
+}
+
+// TypedRune implements fyne.Focusable. The digits set the rating.
+func (r *ratingField) TypedRune(ch rune) {
+	if ch >= '0' && ch <= '9' {
+		r.field.rate(float64(ch - '0'))
+	}
+}
+
+// TypedKey implements fyne.Focusable.
+func (r *ratingField) TypedKey(key *fyne.KeyEvent) {
+	if r.typedNavigationKey(key.Name) {
+		return
+	}
+	f := r.field
+	switch key.Name {
+	case fyne.KeyLeft, fyne.KeyDown:
+		f.rate(f.rating - f.step())
+	case fyne.KeyRight, fyne.KeyUp:
+		f.rate(f.rating + f.step())
+	case fyne.KeyHome, fyne.KeyDelete, fyne.KeyBackspace:
+		f.rate(0)
+	case fyne.KeyEnd:
+		f.rate(float64(f.stars()))
+	}
+}
+
+func (r *ratingField) CreateRenderer() fyne.WidgetRenderer {
+	r.ExtendBaseWidget(r)
+	rr := &ratingFieldRenderer{
+		focus:  canvas.NewRectangle(theme.FocusColor()),
+		widget: r,
+	}
+	rr.Refresh()
+	return rr
+}
+
+type ratingFieldRenderer struct {
+	focus   *canvas.Rectangle
+	stars   []*canvas.Image
+	widget  *ratingField
+	objects []fyne.CanvasObject
+}
+
+func (r *ratingFieldRenderer) Destroy() {}
+
+func (r *ratingFieldRenderer) Layout(size fyne.Size) {
+	pad := theme.Padding()
+	starSize := ratingStarSize()
+	y := (size.Height - starSize) / 2
+	for i, s := range r.stars {
+		s.Move(fyne.NewPos(pad*2+float32(i)*(starSize+pad), y))
+		s.Resize(fyne.NewSize(starSize, starSize))
+	}
+	r.focus.Move(fyne.NewPos(pad, y-pad))
+	r.focus.Resize(fyne.NewSize(float32(len(r.stars))*(starSize+pad)+pad, starSize+pad*2))
+}
+
+func (r *ratingFieldRenderer) MinSize() fyne.Size {
+	pad := theme.Padding()
+	starSize := ratingStarSize()
+	n := float32(r.widget.field.stars())
+	return fyne.NewSize(pad*3+n*(starSize+pad), starSize+pad*4)
+}
+
+func (r *ratingFieldRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *ratingFieldRenderer) Refresh() {
+	w := r.widget
+	f := w.field
+	n := f.stars()
+	for len(r.stars) < n {
+		img := &canvas.Image{FillMode: canvas.ImageFillContain}
+		r.stars = append(r.stars, img)
+	}
+	r.stars = r.stars[:n]
+
+	for i, s := range r.stars {
+		switch rest := f.rating - float64(i); {
+		case rest >= 1:
+			s.Resource = starFullResource
+		case rest >= 0.5:
+			s.Resource = starHalfResource
+		default:
+			s.Resource = starEmptyResource
+		}
+		s.Translucency = 0
+		if f.Disabled() {
+			s.Translucency = 0.5
+		}
+		s.Refresh()
+	}
+
+	r.focus.FillColor = theme.FocusColor()
+	if !w.focused {
+		r.focus.FillColor = theme.HoverColor()
+	}
+	r.focus.Hidden = f.Disabled() || (!w.focused && !w.hovered)
+
+	r.objects = []fyne.CanvasObject{r.focus}
+	for _, s := range r.stars {
+		r.objects = append(r.objects, s)
+	}
+	r.Layout(w.Size())
+	canvas.Refresh(w)
+}
+
+// ===============================================================
+// Private helpers
+// ===============================================================
+
+func ratingStarSize() float32 {
+	return theme.IconInlineSize() * 1.25
+}
+
+const starPath = "M12 2 14.9 8.6 22 9.2 16.6 14 18.2 21 12 17.3 5.8 21 7.4 14 2 9.2 9.1 8.6Z"
+
+var (
+	starFullResource = fyne.NewStaticResource("star-full.svg", []byte(
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24">`+
+			`<path d="`+starPath+`" fill="#f5b400" stroke="#f5b400" stroke-width="1"/></svg>`))
+	starHalfResource = fyne.NewStaticResource("star-half.svg", []byte(
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24">`+
+			`<path d="`+starPath+`" fill="none" stroke="#f5b400" stroke-width="1"/>`+
+			`<path d="M12 2 9.1 8.6 2 9.2 7.4 14 5.8 21 12 17.3Z" fill="#f5b400" stroke="#f5b400" stroke-width="1"/></svg>`))
+	starEmptyResource = fyne.NewStaticResource("star-empty.svg", []byte(
+		`<svg xmlns="http://www.w3.org/2000/svg" viewBox="0 0 24 24">`+
+			`<path d="`+starPath+`" fill="none" stroke="#9e9e9e" stroke-width="1"/></svg>`))
+)
@@ -0,0 +1,62 @@
+package swid
+
+import (
+	"errors"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRatingFormField(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	r := NewRatingFormField("Rating", 5, 0)
+	r.AllowHalf = true
+	r.Validator = func(rating float64) error {
+		if rating == 0 {
+			return errors.New("rating required")
+		}
+		return nil
+	}
+	var saved float64
+	r.OnSaved = func(rating float64) { saved = rating }
+
+	form := NewForm(1, r)
+	w := test.NewWindow(form)
+	w.Resize(fyne.NewSize(300, 200))
+	defer w.Close()
+
+	assert.EqualError(t, r.ValidationError(), "rating required")
+	assert.False(t, form.IsValid())
+
+	// a tap on the left half of the third star
+	pad := theme.Padding()
+	size := ratingStarSize()
+	x := pad*2 + 2*(size+pad) + size/4
+	test.TapAt(r.field, fyne.NewPos(x, 10))
+	assert.Equal(t, 2.5, r.Rating())
+	assert.True(t, form.IsValid())
+
+	// tapping the current rating clears it
+	test.TapAt(r.field, fyne.NewPos(x, 10))
+	assert.Equal(t, 0.0, r.Rating())
+
+	r.field.TypedRune('4')
+	r.field.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight})
+	assert.Equal(t, 4.5, r.Rating())
+	r.field.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight})
+	r.field.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight})
+	assert.Equal(t, 5.0, r.Rating())
+
+	r.Save()
+	assert.Equal(t, 5.0, saved)
+
+	r.AllowHalf = false
+	r.SetRating(3.4)
+	assert.Equal(t, 3.0, r.Rating())
+
+	r.Reset()
+	assert.Equal(t, 0.0, r.Rating())
+}
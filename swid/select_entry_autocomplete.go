@@ -0,0 +1,419 @@
+package swid
+
+import (
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+)
+
+// OptionMatch defines how the options of a SelectEntryFormField are
+// matched with the typed text.
+type OptionMatch int
+
+// OptionMatch options
+const (
+	// OptionMatchNone does not show suggestions while typing.
+	OptionMatchNone OptionMatch = iota
+	// OptionMatchSubstring suggests the options that contain the typed
+	// text, ignoring the case.
+	OptionMatchSubstring
+	// OptionMatchFuzzy suggests the options that contain the characters of
+	// the typed text in order (like "nyc" for "New York City"), ignoring
+	// the case.
+	OptionMatchFuzzy
+)
+
+const defaultSearchDelay = 300 * time.Millisecond
+
+// Messages shown by the suggestions dropdown.
+var (
+	SuggestionsLoadingText   = "Loading..."
+	SuggestionsNoResultsText = "No results"
+)
+
+// ===============================================================
+// Autocomplete
+// ===============================================================
+
+// search shows the suggestions for the typed text, calling the options
+// provider after the search delay or matching the options.
+func (s *SelectEntryFormField) search(query string) {
+	if s.OptionsProvider == nil && s.OptionMatch == OptionMatchNone {
+		return
+	}
+	if strings.TrimSpace(query) == "" {
+		s.cancelSearch()
+		s.hideSuggestions()
+		return
+	}
+	if s.OptionsProvider == nil {
+		s.showSuggestions(matchOptions(s.options, query, s.OptionMatch), "")
+		return
+	}
+
+	s.cancelSearch()
+	// the pop up is shown here and not in the timer goroutine, which only
+	// updates its items.
+	s.showSuggestions(nil, SuggestionsLoadingText)
+	s.searchLock.Lock()
+	defer s.searchLock.Unlock()
+	seq := s.searchSeq
+	delay := s.SearchDelay
+	if delay <= 0 {
+		delay = defaultSearchDelay
+	}
+	s.searchTimer = time.AfterFunc(delay, func() {
+		s.runSearch(seq, query)
+	})
+}
+
+// runSearch calls the options provider and shows the results if the
+// search is still the current one and its suggestions are still shown.
+func (s *SelectEntryFormField) runSearch(seq int, query string) {
+	if !s.isCurrentSearch(seq) {
+		return
+	}
+	results, err := s.OptionsProvider(query)
+
+	s.searchLock.Lock()
+	defer s.searchLock.Unlock()
+	if seq != s.searchSeq || s.popUp == nil || !s.popUp.Visible() {
+		return
+	}
+	if err != nil {
+		s.suggestions.setItems(nil, err.Error())
+	} else {
+		s.addKnownOptions(results)
+		s.suggestions.setItems(results, suggestionsMessage(results))
+	}
+	resizeSuggestionPopUp(s.popUp, s.selectEntryField)
+}
+
+// cancelSearch discards the pending and running searches.
+func (s *SelectEntryFormField) cancelSearch() {
+	s.searchLock.Lock()
+	defer s.searchLock.Unlock()
+	if s.searchTimer != nil {
+		s.searchTimer.Stop()
+	}
+	s.searchSeq++
+}
+
+func (s *SelectEntryFormField) isCurrentSearch(seq int) bool {
+	s.searchLock.Lock()
+	defer s.searchLock.Unlock()
+	return seq == s.searchSeq
+}
+
+// showSuggestions shows the dropdown with the suggestions, or with the
+// message if it is not empty.
+func (s *SelectEntryFormField) showSuggestions(items []string, message string) {
+	if message == "" {
+		message = suggestionsMessage(items)
+	}
+	s.searchLock.Lock()
+	defer s.searchLock.Unlock()
+	if s.suggestions == nil {
+		s.suggestions = newSuggestionList(s)
+	}
+	s.suggestions.setItems(items, message)
+	s.popUp = showSuggestionPopUp(s.popUp, s.suggestions, s.selectEntryField)
+}
+
+func (s *SelectEntryFormField) suggestionInput() suggestionInput {
+	return s.selectEntryField
+}
+
+func (s *SelectEntryFormField) hideSuggestions() {
+	s.searchLock.Lock()
+	defer s.searchLock.Unlock()
+	if s.popUp != nil {
+		s.popUp.Hide()
+		s.popUp = nil
+	}
+}
+
+// selectSuggestion sets the text to the suggestion and hides the dropdown.
+func (s *SelectEntryFormField) selectSuggestion(item string) {
+	s.cancelSearch()
+	s.hideSuggestions()
+	s.rememberOptions([]string{item})
+	s.SetText(item)
+	s.selectEntryField.CursorColumn = len([]rune(item))
+	s.selectEntryField.Refresh()
+}
+
+// rememberOptions adds the options to the ones accepted by
+// RestrictToOptions.
+func (s *SelectEntryFormField) rememberOptions(options []string) {
+	s.searchLock.Lock()
+	defer s.searchLock.Unlock()
+	s.addKnownOptions(options)
+}
+
+// addKnownOptions adds the options to the ones accepted by
+// RestrictToOptions. The searchLock must be held.
+func (s *SelectEntryFormField) addKnownOptions(options []string) {
+	if s.knownOptions == nil {
+		s.knownOptions = make(map[string]bool)
+	}
+	for _, o := range options {
+		s.knownOptions[o] = true
+	}
+}
+
+func (s *SelectEntryFormField) knownOptionList() []string {
+	s.searchLock.Lock()
+	defer s.searchLock.Unlock()
+	options := make([]string, 0, len(s.knownOptions))
+	for o := range s.knownOptions {
+		options = append(options, o)
+	}
+	return options
+}
+
+// ===============================================================
+// Suggestion list
+// ===============================================================
+
+// suggestionOwner is a field that shows its suggestions with a
+// suggestionList, like SelectEntryFormField and TagsFormField.
+type suggestionOwner interface {
+	// suggestionInput returns the entry that receives the keys typed while
+	// the suggestions are shown.
+	suggestionInput() suggestionInput
+	selectSuggestion(item string)
+	hideSuggestions()
+	cancelSearch()
+}
+
+type suggestionInput interface {
+	fyne.Focusable
+	fyne.Shortcutable
+}
+
+// suggestionList shows the suggestions of a field. While it is shown, it
+// has the keyboard focus: it handles the up, down, enter and escape keys,
+// and forwards the rest to the entry of the field.
+type suggestionList struct {
+	widget.BaseWidget
+	field suggestionOwner
+
+	// lock guards the items and the renderer, as the results of an
+	// options provider are set from the goroutine of the search.
+	lock     sync.Mutex
+	items    []string
+	message  string
+	selected int
+}
+
+func newSuggestionList(field suggestionOwner) *suggestionList {
+	l := &suggestionList{field: field, selected: -1}
+	l.ExtendBaseWidget(l)
+	return l
+}
+
+func (l *suggestionList) setItems(items []string, message string) {
+	l.lock.Lock()
+	l.items = items
+	l.message = message
+	l.selected = -1
+	l.lock.Unlock()
+	l.Refresh()
+}
+
+// FocusGained implements fyne.Focusable.
+func (l *suggestionList) FocusGained() {}
+
+// FocusLost implements fyne.Focusable.
+func (l *suggestionList) FocusLost() {}
+
+// TypedRune implements fyne.Focusable.
+func (l *suggestionList) TypedRune(r rune) {
+	l.field.suggestionInput().TypedRune(r)
+}
+
+// TypedKey implements fyne.Focusable.
+func (l *suggestionList) TypedKey(key *fyne.KeyEvent) {
+	switch key.Name {
+	case fyne.KeyDown:
+		l.moveSelection(1)
+	case fyne.KeyUp:
+		l.moveSelection(-1)
+	case fyne.KeyReturn, fyne.KeyEnter:
+		if item, ok := l.selectedItem(); ok {
+			l.field.selectSuggestion(item)
+			return
+		}
+		l.field.hideSuggestions()
+		l.field.suggestionInput().TypedKey(key)
+	case fyne.KeyEscape:
+		l.field.cancelSearch()
+		l.field.hideSuggestions()
+	case fyne.KeyTab:
+		l.field.hideSuggestions()
+		l.field.suggestionInput().TypedKey(key)
+	default:
+		l.field.suggestionInput().TypedKey(key)
+	}
+}
+
+// moveSelection moves the selected item by step, wrapping around.
+func (l *suggestionList) moveSelection(step int) {
+	l.lock.Lock()
+	n := len(l.items)
+	if n == 0 {
+		l.lock.Unlock()
+		return
+	}
+	l.selected = ((l.selected+step)%n + n) % n
+	l.lock.Unlock()
+	l.Refresh()
+}
+
+func (l *suggestionList) selectedItem() (string, bool) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.selected < 0 {
+		return "", false
+	}
+	return l.items[l.selected], true
+}
+
+// TypedShortcut implements fyne.Shortcutable.
+func (l *suggestionList) TypedShortcut(sc fyne.Shortcut) {
+	l.field.suggestionInput().TypedShortcut(sc)
+}
+
+func (l *suggestionList) CreateRenderer() fyne.WidgetRenderer {
+	l.ExtendBaseWidget(l)
+	r := &suggestionListRenderer{list: l, content: container.NewVBox()}
+	r.Refresh()
+	return r
+}
+
+type suggestionListRenderer struct {
+	list    *suggestionList
+	content *fyne.Container
+	items   []*widget.Button
+}
+
+func (r *suggestionListRenderer) Destroy() {}
+
+func (r *suggestionListRenderer) Layout(size fyne.Size) {
+	r.content.Resize(size)
+}
+
+func (r *suggestionListRenderer) MinSize() fyne.Size {
+	return r.content.MinSize()
+}
+
+func (r *suggestionListRenderer) Objects() []fyne.CanvasObject {
+	return []fyne.CanvasObject{r.content}
+}
+
+func (r *suggestionListRenderer) Refresh() {
+	l := r.list
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.message != "" {
+		r.items = nil
+		r.content.Objects = []fyne.CanvasObject{widget.NewLabel(l.message)}
+		r.content.Refresh()
+		return
+	}
+	r.items = make([]*widget.Button, len(l.items))
+	objects := make([]fyne.CanvasObject, len(l.items))
+	for i, item := range l.items {
+		item := item
+		b := widget.NewButton(item, func() { l.field.selectSuggestion(item) })
+		b.Alignment = widget.ButtonAlignLeading
+		b.Importance = widget.LowImportance
+		if i == l.selected {
+			b.Importance = widget.MediumImportance
+		}
+		r.items[i] = b
+		objects[i] = b
+	}
+	r.content.Objects = objects
+	r.content.Refresh()
+}
+
+// ===============================================================
+// Private helpers
+// ===============================================================
+
+// showSuggestionPopUp shows the list in a pop up below the object and
+// gives it the keyboard focus, so it forwards the typed keys to the
+// entry. A pop up that is still visible is only resized, but a new one is
+// created if it was dismissed (like with a tap outside of it). It returns
+// nil if the object is not in a canvas.
+func showSuggestionPopUp(popUp *widget.PopUp, list *suggestionList, below fyne.CanvasObject) *widget.PopUp {
+	if popUp != nil && popUp.Visible() {
+		resizeSuggestionPopUp(popUp, below)
+		return popUp
+	}
+	c := fyne.CurrentApp().Driver().CanvasForObject(below)
+	if c == nil {
+		return nil
+	}
+	popUp = widget.NewPopUp(list, c)
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(below)
+	popUp.ShowAtPosition(pos.Add(fyne.NewPos(0, below.Size().Height)))
+	resizeSuggestionPopUp(popUp, below)
+	c.Focus(list)
+	return popUp
+}
+
+// resizeSuggestionPopUp fits the pop up to its items and to the width of
+// the object it is shown below.
+func resizeSuggestionPopUp(popUp *widget.PopUp, below fyne.CanvasObject) {
+	popUp.Resize(fyne.NewSize(below.Size().Width, popUp.MinSize().Height))
+}
+
+// suggestionsMessage returns the message shown when there are no items.
+func suggestionsMessage(items []string) string {
+	if len(items) == 0 {
+		return SuggestionsNoResultsText
+	}
+	return ""
+}
+
+// matchOptions returns the options that match the query.
+func matchOptions(options []string, query string, match OptionMatch) []string {
+	query = strings.ToLower(strings.TrimSpace(query))
+	matches := make([]string, 0, len(options))
+	for _, o := range options {
+		lower := strings.ToLower(o)
+		if match == OptionMatchFuzzy && fuzzyMatch(lower, query) ||
+			match != OptionMatchFuzzy && strings.Contains(lower, query) {
+			matches = append(matches, o)
+		}
+	}
+	return matches
+}
+
+// fuzzyMatch returns true if the text contains the characters of the
+// query in order. The spaces of the query are ignored.
+func fuzzyMatch(text, query string) bool {
+	t := []rune(text)
+	i := 0
+	for _, r := range query {
+		if unicode.IsSpace(r) {
+			continue
+		}
+		for i < len(t) && t[i] != r {
+			i++
+		}
+		if i == len(t) {
+			return false
+		}
+		i++
+	}
+	return true
+}
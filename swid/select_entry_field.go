@@ -11,6 +11,10 @@ type SelectEntryField struct {
 
 	focused        bool
 	onFocusChanged func(bool)
+
+	onTypedShortcut func(fyne.Shortcut) bool
+
+	fieldKeys
 }
 
 // NewSelectEntryField creates a new select entry field.
@@ -49,3 +53,39 @@ func (s *SelectEntryField) FocusLost() {
 		s.onFocusChanged(false)
 	}
 }
+
+// TypedShortcut overrides widget.SelectEntry method.
+func (s *SelectEntryField) TypedShortcut(sc fyne.Shortcut) {
+	if s.onTypedShortcut != nil && s.onTypedShortcut(sc) {
+		return
+	}
+	s.SelectEntry.TypedShortcut(sc)
+}
+
+// AcceptsTab overrides widget.SelectEntry method.
+func (s *SelectEntryField) AcceptsTab() bool {
+	if s.MultiLine || s.keyHandler == nil {
+		return s.SelectEntry.AcceptsTab()
+	}
+	return s.fieldKeys.AcceptsTab()
+}
+
+// KeyDown overrides widget.SelectEntry method.
+func (s *SelectEntryField) KeyDown(key *fyne.KeyEvent) {
+	s.fieldKeys.KeyDown(key)
+	s.SelectEntry.KeyDown(key)
+}
+
+// KeyUp overrides widget.SelectEntry method.
+func (s *SelectEntryField) KeyUp(key *fyne.KeyEvent) {
+	s.fieldKeys.KeyUp(key)
+	s.SelectEntry.KeyUp(key)
+}
+
+// TypedKey overrides widget.SelectEntry method.
+func (s *SelectEntryField) TypedKey(key *fyne.KeyEvent) {
+	if !s.MultiLine && s.typedNavigationKey(key.Name) {
+		return
+	}
+	s.SelectEntry.TypedKey(key)
+}
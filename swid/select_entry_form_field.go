@@ -1,7 +1,12 @@
 package swid
 
 import (
+	"sync"
+	"time"
+
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/widget"
+	"github.com/fpabl0/sparky-go/svalid"
 )
 
 // SelectEntryFormField defines a special select entry field for Forms.
@@ -13,12 +18,35 @@ type SelectEntryFormField struct {
 	Wrapping    fyne.TextWrap
 	Validator   fyne.StringValidator
 
+	// OptionsProvider enables the autocomplete mode. It is called with the
+	// typed text after SearchDelay, in a new goroutine, so it can block
+	// (like a request to an API). The results are shown as suggestions.
+	OptionsProvider func(query string) ([]string, error)
+	// SearchDelay is the debounce delay of OptionsProvider. It defaults to
+	// 300 milliseconds.
+	SearchDelay time.Duration
+	// OptionMatch defines how the options are filtered while typing, when
+	// there is no OptionsProvider.
+	OptionMatch OptionMatch
+	// RestrictToOptions makes the field invalid if its text is not one of
+	// the options or suggestions.
+	RestrictToOptions bool
+
 	OnChanged func(string) `json:"-"`
 	OnSaved   func(s string)
 
 	selectEntryField *SelectEntryField
 	initialText      string
 	resetOverrideErr bool
+
+	options      []string
+	knownOptions map[string]bool
+	suggestions  *suggestionList
+	popUp        *widget.PopUp
+	searchTimer  *time.Timer
+	searchSeq    int
+	settingText  bool
+	searchLock   sync.Mutex
 }
 
 // NewSelectEntryFormField creates a new select entry form field.
@@ -29,6 +57,8 @@ func NewSelectEntryFormField(label, initialValue string, options []string) *Sele
 	s.Wrapping = fyne.TextTruncate
 	s.initialText = initialValue
 	s.setupSelectEntryField(options)
+	s.options = append([]string{}, options...)
+	s.rememberOptions(options)
 	return s
 }
 
@@ -45,19 +75,25 @@ func (s *SelectEntryFormField) Text() string {
 func (s *SelectEntryFormField) SetText(text string) {
 	// use this instead t.textField.Text to ensure we trigger the onChanged callback.
 	// TODO should this be fixed by Fyne??
+	s.settingText = true
 	s.selectEntryField.SetText(text)
+	s.settingText = false
 	s.Refresh() // refresh the whole widget
 }
 
 // SetOptions sets the options the user might select from.
 func (s *SelectEntryFormField) SetOptions(options []string) {
 	s.selectEntryField.SetOptions(options)
+	s.options = append([]string{}, options...)
+	s.rememberOptions(options)
 }
 
 // Reset resets the text value to the initial value.
 func (s *SelectEntryFormField) Reset() {
-	s.dirty = false
+	s.resetDirty()
+	s.forceValidation = true
 	s.SetText(s.initialText)
+	s.forceValidation = false
 	s.resetOverrideErr = true
 	s.selectEntryField.SetValidationError(nil)
 	s.resetOverrideErr = false
@@ -71,9 +107,17 @@ func (s *SelectEntryFormField) Save() {
 	}
 }
 
+func (s *SelectEntryFormField) draftValue() (string, bool) {
+	return s.selectEntryField.Text, true
+}
+
+func (s *SelectEntryFormField) restoreDraftValue(v string) {
+	s.SetText(v)
+}
+
 // ValidationError returns the underlying validation error.
 func (s *SelectEntryFormField) ValidationError() error {
-	if s.Validator != nil {
+	if s.hasValidator() {
 		// means that this was called before CreateRenderer and
 		// then Validator field is not copy to the selectEntryField yet,
 		// so Refresh to generate it
@@ -88,7 +132,7 @@ func (s *SelectEntryFormField) ValidationError() error {
 
 // Validate validates the field.
 func (s *SelectEntryFormField) Validate() error {
-	if s.Validator != nil {
+	if s.hasValidator() {
 		// means that this was called before CreateRenderer and
 		// then Validator field is not copy to the selectEntryField yet,
 		// so Refresh to generate it
@@ -96,15 +140,55 @@ func (s *SelectEntryFormField) Validate() error {
 			s.ExtendBaseFormField(s)
 			s.Refresh()
 		}
-		return s.selectEntryField.Validate()
+		return s.forceValidate(s.selectEntryField.Validate)
 	}
 	return nil
 }
 
+// fieldValidator returns the validator used by the internal widget.
+func (s *SelectEntryFormField) fieldValidator() fyne.StringValidator {
+	if !s.hasValidator() {
+		return nil
+	}
+	return s.runValidator
+}
+
+// runValidator runs the Validator only if the validation trigger allows it,
+// otherwise it keeps the current validation error.
+func (s *SelectEntryFormField) runValidator(text string) error {
+	if !s.hasValidator() {
+		return nil
+	}
+	if !s.shouldRunValidator() {
+		return s.validationError
+	}
+	if s.RestrictToOptions {
+		if err := svalid.OneOf(s.knownOptionList())(text); err != nil {
+			return err
+		}
+	}
+	if s.Validator == nil {
+		return nil
+	}
+	return s.Validator(text)
+}
+
+func (s *SelectEntryFormField) hasValidator() bool {
+	return s.Validator != nil || s.RestrictToOptions
+}
+
+func (s *SelectEntryFormField) focusTarget() fyne.Focusable {
+	return s.selectEntryField
+}
+
 func (s *SelectEntryFormField) setupSelectEntryField(options []string) {
 	s.selectEntryField = NewSelectEntryField(options)
 	s.selectEntryField.Text = s.initialText
+	s.selectEntryField.onTypedShortcut = s.typedShortcut
 	s.selectEntryField.OnChanged = func(text string) {
+		if !s.settingText && s.selectEntryField.focused {
+			s.search(text)
+		}
 		if s.OnChanged != nil {
 			s.OnChanged(text)
 		}
@@ -113,12 +197,20 @@ func (s *SelectEntryFormField) setupSelectEntryField(options []string) {
 			s.Refresh()
 		}
 	}
+	s.selectEntryField.keyHandler = &s.BaseFormField
 	s.selectEntryField.onFocusChanged = func(focused bool) {
 		if focused && !s.dirty {
 			// handle special case to validate automatically a field
 			// after a Reset call
 			s.Validate()
 		}
+		if !focused {
+			s.hideSuggestions()
+			s.blurred = true
+			if s.validateOnBlur() {
+				s.Validate()
+			}
+		}
 		s.Refresh()
 	}
 	s.selectEntryField.SetOnValidationChanged(func(e error) {
@@ -143,8 +235,8 @@ func (s *SelectEntryFormField) setupSelectEntryField(options []string) {
 func (s *SelectEntryFormField) CreateRenderer() fyne.WidgetRenderer {
 	s.ExtendBaseFormField(s)
 
-	s.selectEntryField.Validator = s.Validator
-	s.selectEntryField.Validate() // validates as soon as it is created
+	s.selectEntryField.Validator = s.fieldValidator()
+	s.forceValidate(s.selectEntryField.Validate) // validates as soon as it is created
 
 	isFieldEmpty := func() bool {
 		return s.selectEntryField.Text == ""
@@ -165,7 +257,7 @@ func (s *SelectEntryFormField) CreateRenderer() fyne.WidgetRenderer {
 			s.selectEntryField.SetPlaceHolder("")
 		}
 		s.selectEntryField.Wrapping = s.Wrapping
-		s.selectEntryField.Validator = s.Validator
+		s.selectEntryField.Validator = s.fieldValidator()
 		if s.Disabled() {
 			s.selectEntryField.Disable()
 		} else {
@@ -2,7 +2,10 @@ package swid
 
 import (
 	"errors"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/test"
@@ -350,3 +353,133 @@ func TestSelectEntryFormField_EmptyValidatorReset(t *testing.T) {
 	assert.NotNil(t, tf.validationError)
 	assert.Equal(t, emptyErr, tf.validationError)
 }
+
+func TestSelectEntryFormField_LocalMatch(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	s := NewSelectEntryFormField("City", "", []string{"New York City", "Newcastle", "Boston"})
+	s.OptionMatch = OptionMatchFuzzy
+	s.RestrictToOptions = true
+
+	w := test.NewWindow(s)
+	w.Resize(fyne.NewSize(200, 100))
+	defer w.Close()
+
+	w.Canvas().Focus(s.selectEntryField)
+	test.Type(s.selectEntryField, "nyc")
+	require.NotNil(t, s.popUp)
+	assert.Equal(t, []string{"New York City"}, s.suggestions.items)
+	assert.Equal(t, s.suggestions, w.Canvas().Focused())
+
+	// the keys typed while the suggestions are shown go to the entry.
+	test.Type(w.Canvas().Focused(), "x")
+	assert.Equal(t, "nycx", s.Text())
+	assert.Equal(t, SuggestionsNoResultsText, s.suggestions.message)
+	s.suggestions.TypedKey(&fyne.KeyEvent{Name: fyne.KeyBackspace})
+	assert.Equal(t, "nyc", s.Text())
+	assert.Error(t, s.Validate())
+
+	s.suggestions.TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	s.suggestions.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	assert.Equal(t, "New York City", s.Text())
+	assert.Nil(t, s.popUp)
+	assert.NoError(t, s.Validate())
+
+	s.OptionMatch = OptionMatchSubstring
+	s.SetText("")
+	test.Type(s.selectEntryField, "new")
+	assert.Equal(t, []string{"New York City", "Newcastle"}, s.suggestions.items)
+	s.suggestions.TypedKey(&fyne.KeyEvent{Name: fyne.KeyEscape})
+	assert.Nil(t, s.popUp)
+}
+
+func TestSelectEntryFormField_OptionsProvider(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	customers := []string{"Alice", "Alan", "Bob"}
+	var calls int32
+	s := NewSelectEntryFormField("Customer", "", nil)
+	s.SearchDelay = 10 * time.Millisecond
+	s.RestrictToOptions = true
+	s.OptionsProvider = func(query string) ([]string, error) {
+		atomic.AddInt32(&calls, 1)
+		if query == "err" {
+			return nil, errors.New("service unavailable")
+		}
+		var results []string
+		for _, c := range customers {
+			if strings.HasPrefix(strings.ToLower(c), query) {
+				results = append(results, c)
+			}
+		}
+		return results, nil
+	}
+
+	w := test.NewWindow(s)
+	w.Resize(fyne.NewSize(200, 100))
+	defer w.Close()
+
+	w.Canvas().Focus(s.selectEntryField)
+	test.Type(s.selectEntryField, "al")
+	assert.Equal(t, SuggestionsLoadingText, s.suggestions.message)
+	r := test.WidgetRenderer(s.suggestions).(*suggestionListRenderer)
+	assert.Eventually(t, func() bool {
+		s.suggestions.lock.Lock()
+		defer s.suggestions.lock.Unlock()
+		return len(r.items) == 2
+	}, time.Second, 5*time.Millisecond)
+	// debounced: only the last query is searched.
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+	test.Tap(r.items[1])
+	assert.Equal(t, "Alan", s.Text())
+	assert.NoError(t, s.Validate())
+
+	s.SetText("")
+	test.Type(s.selectEntryField, "err")
+	assert.Eventually(t, func() bool {
+		s.suggestions.lock.Lock()
+		defer s.suggestions.lock.Unlock()
+		return s.suggestions.message == "service unavailable"
+	}, time.Second, 5*time.Millisecond)
+
+	s.SetText("Zoe")
+	assert.Error(t, s.Validate())
+
+	// the results of a search are not shown if the pop up was dismissed,
+	// but typing again shows the suggestions.
+	s.SetText("")
+	test.Type(s.selectEntryField, "b")
+	popUp := s.popUp
+	test.Tap(popUp)
+	assert.False(t, popUp.Visible())
+	time.Sleep(5 * s.SearchDelay)
+	assert.False(t, popUp.Visible())
+	test.Type(s.selectEntryField, "o")
+	assert.True(t, s.popUp.Visible())
+	assert.Eventually(t, func() bool {
+		s.suggestions.lock.Lock()
+		defer s.suggestions.lock.Unlock()
+		return len(r.items) == 1 && r.items[0].Text == "Bob"
+	}, time.Second, 5*time.Millisecond)
+}
+
+func TestSelectEntryFormField_DismissSuggestions(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	s := NewSelectEntryFormField("City", "", []string{"New York City", "Newcastle", "Boston"})
+	s.OptionMatch = OptionMatchSubstring
+
+	w := test.NewWindow(s)
+	w.Resize(fyne.NewSize(200, 100))
+	defer w.Close()
+
+	w.Canvas().Focus(s.selectEntryField)
+	test.Type(s.selectEntryField, "new")
+	assert.True(t, s.popUp.Visible())
+
+	// a tap outside of the list dismisses the pop up.
+	test.Tap(s.popUp)
+	assert.False(t, s.popUp.Visible())
+
+	test.Type(s.selectEntryField, "c")
+	assert.True(t, s.popUp.Visible())
+	assert.Equal(t, []string{"Newcastle"}, s.suggestions.items)
+}
@@ -3,6 +3,7 @@ package swid
 import (
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
@@ -17,6 +18,16 @@ type SelectField struct {
 
 	focused        bool
 	onFocusChanged func(bool)
+
+	fieldKeys
+
+	// items are the options with values, disabled options and groups. If
+	// they are set, the field shows its own dropdown.
+	items []SelectOption
+	// selectedItem is the index of the selected item, so items with the
+	// same label (in different groups) can be told apart.
+	selectedItem int
+	popUp        *widget.PopUp
 }
 
 // NewSelectField creates a new select field widget.
@@ -25,6 +36,7 @@ func NewSelectField(options []string, changed func(string)) *SelectField {
 	s.ExtendBaseWidget(s)
 	s.Options = options
 	s.OnChanged = changed
+	s.selectedItem = -1
 	return s
 }
 
@@ -80,9 +92,124 @@ func (s *SelectField) Tapped(ev *fyne.PointEvent) {
 		return
 	}
 	fyne.CurrentApp().Driver().CanvasForObject(s).Focus(s)
+	if s.items != nil {
+		s.showOptions()
+		return
+	}
 	s.Select.Tapped(ev)
 }
 
+// TypedKey overrides widget.Select method.
+func (s *SelectField) TypedKey(key *fyne.KeyEvent) {
+	if s.typedNavigationKey(key.Name) {
+		return
+	}
+	if s.items != nil && !s.Disabled() {
+		switch key.Name {
+		case fyne.KeySpace, fyne.KeyUp, fyne.KeyDown:
+			s.showOptions()
+			return
+		case fyne.KeyRight:
+			s.selectEnabled(1)
+			return
+		case fyne.KeyLeft:
+			s.selectEnabled(-1)
+			return
+		}
+	}
+	s.Select.TypedKey(key)
+}
+
+// showOptions shows the dropdown with the items, including the group
+// headers and the disabled items.
+func (s *SelectField) showOptions() {
+	c := fyne.CurrentApp().Driver().CanvasForObject(s)
+	if c == nil {
+		return
+	}
+	list := container.NewVBox()
+	group := ""
+	selected := s.selectedItemIndex()
+	for i, item := range s.items {
+		if item.Group != group {
+			group = item.Group
+			if group != "" {
+				list.Add(widget.NewLabelWithStyle(group, fyne.TextAlignLeading, fyne.TextStyle{Bold: true}))
+			}
+		}
+		index := i
+		b := widget.NewButton(item.Label, func() {
+			s.hideOptions()
+			s.setSelectedItem(index)
+		})
+		b.Alignment = widget.ButtonAlignLeading
+		b.Importance = widget.LowImportance
+		if i == selected {
+			b.Importance = widget.MediumImportance
+		}
+		if item.Disabled {
+			b.Disable()
+		}
+		list.Add(b)
+	}
+	s.popUp = widget.NewPopUp(list, c)
+	pos := fyne.CurrentApp().Driver().AbsolutePositionForObject(s)
+	s.popUp.ShowAtPosition(pos.Add(fyne.NewPos(0, s.Size().Height-theme.InputBorderSize())))
+	s.popUp.Resize(fyne.NewSize(s.Size().Width, s.popUp.MinSize().Height))
+}
+
+func (s *SelectField) hideOptions() {
+	if s.popUp != nil {
+		s.popUp.Hide()
+		s.popUp = nil
+	}
+}
+
+// selectEnabled selects the next (or the previous if step is negative)
+// item that is not disabled, wrapping around.
+func (s *SelectField) selectEnabled(step int) {
+	n := len(s.items)
+	current := s.selectedItemIndex()
+	if current < 0 && step < 0 {
+		current = n
+	}
+	for i := 1; i <= n; i++ {
+		index := ((current+step*i)%n + n) % n
+		if !s.items[index].Disabled {
+			s.setSelectedItem(index)
+			return
+		}
+	}
+}
+
+// selectedItemIndex returns the index of the selected item, or -1 if no
+// item is selected. If the selection was changed by label (like with
+// SetSelected), the first item with that label is used.
+func (s *SelectField) selectedItemIndex() int {
+	if s.Selected == "" {
+		return -1
+	}
+	if s.selectedItem >= 0 && s.selectedItem < len(s.items) && s.items[s.selectedItem].Label == s.Selected {
+		return s.selectedItem
+	}
+	for i, item := range s.items {
+		if item.Label == s.Selected {
+			return i
+		}
+	}
+	return -1
+}
+
+// setSelectedItem selects the item at the index and triggers OnChanged.
+func (s *SelectField) setSelectedItem(index int) {
+	s.selectedItem = index
+	s.Selected = s.items[index].Label
+	if s.OnChanged != nil {
+		s.OnChanged(s.Selected)
+	}
+	s.Refresh()
+}
+
 // ===============================================================
 // Renderer
 // ===============================================================
@@ -2,11 +2,24 @@ package swid
 
 import (
 	"image/color"
+	"strconv"
 
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/theme"
 )
 
+// SelectOption defines an option of a SelectFormField with a value that
+// is different from the label shown to the user (like an ID). The values
+// must be comparable.
+type SelectOption struct {
+	Value interface{}
+	Label string
+	// Group is shown as a header before the first option of each run of
+	// options with the same group.
+	Group    string
+	Disabled bool
+}
+
 // SelectFormField defines a special select field for Forms.
 type SelectFormField struct {
 	BaseFormField
@@ -17,10 +30,16 @@ type SelectFormField struct {
 
 	OnChanged func(string) `json:"-"`
 	OnSaved   func(s string)
+	// OnValueSaved is called with the value of the selected option, for
+	// the fields created with NewSelectFormFieldWithValues.
+	OnValueSaved func(v interface{})
 
 	selectField  *SelectField
 	initialValue string
 	isRendered   bool // TODO remove when Fyne has a way to check if the widget has been renderered or not
+	// initialOptionValue is used by Reset when the field has options with
+	// values, so the initial option is found even if the labels changed.
+	initialOptionValue interface{}
 }
 
 // NewSelectFormField creates a new select form field.
@@ -34,10 +53,73 @@ func NewSelectFormField(label, initialValue string, options []string) *SelectFor
 	return s
 }
 
+// NewSelectFormFieldWithValues creates a new select form field with
+// options that have values, disabled options or groups.
+func NewSelectFormFieldWithValues(label string, initialValue interface{}, options []SelectOption) *SelectFormField {
+	s := NewSelectFormField(label, "", nil)
+	s.initialOptionValue = initialValue
+	s.SetSelectOptions(options)
+	s.selectValue(initialValue)
+	s.initialValue = s.selectField.Selected
+	return s
+}
+
 // ===============================================================
 // Methods
 // ===============================================================
 
+// SetSelectOptions sets the options with values. The selected option is
+// kept if its value is still present, even if its label changed (like
+// when the labels are translated).
+func (s *SelectFormField) SetSelectOptions(options []SelectOption) {
+	value := s.SelectedValue()
+	s.selectField.items = append([]SelectOption{}, options...)
+	s.Options = make([]string, len(options))
+	for i, o := range options {
+		s.Options[i] = o.Label
+	}
+	s.selectValue(value)
+	s.Refresh()
+}
+
+// SelectOptions returns the options with values.
+func (s *SelectFormField) SelectOptions() []SelectOption {
+	return append([]SelectOption{}, s.selectField.items...)
+}
+
+// SelectedValue returns the value of the selected option, or nil if no
+// option is selected.
+func (s *SelectFormField) SelectedValue() interface{} {
+	if i := s.selectField.selectedItemIndex(); i >= 0 {
+		return s.selectField.items[i].Value
+	}
+	return nil
+}
+
+// SetSelectedValue selects the option with the value. It clears the
+// selection if there is no option with the value.
+func (s *SelectFormField) SetSelectedValue(v interface{}) {
+	s.selectValue(v)
+	s.Refresh() // refresh the whole widget
+}
+
+// selectValue selects the option with the value without triggering
+// OnChanged.
+func (s *SelectFormField) selectValue(v interface{}) {
+	s.selectField.selectedItem = -1
+	s.selectField.Selected = ""
+	if v == nil {
+		return
+	}
+	for i, o := range s.selectField.items {
+		if o.Value == v {
+			s.selectField.selectedItem = i
+			s.selectField.Selected = o.Label
+			return
+		}
+	}
+}
+
 // Selected returns the selected value.
 func (s *SelectFormField) Selected() string {
 	return s.selectField.Selected
@@ -51,8 +133,12 @@ func (s *SelectFormField) SetSelected(text string) {
 
 // Reset resets the text value to the initial value.
 func (s *SelectFormField) Reset() {
-	s.dirty = false
-	s.SetSelected(s.initialValue)
+	s.resetDirty()
+	if s.selectField.items != nil {
+		s.SetSelectedValue(s.initialOptionValue)
+	} else {
+		s.SetSelected(s.initialValue)
+	}
 	s.didChange()
 }
 
@@ -61,6 +147,38 @@ func (s *SelectFormField) Save() {
 	if s.OnSaved != nil {
 		s.OnSaved(s.selectField.Selected)
 	}
+	if s.OnValueSaved != nil {
+		s.OnValueSaved(s.SelectedValue())
+	}
+}
+
+// draftValue returns the index of the selected option when the options
+// have values, so options with the same label are told apart and the
+// selection survives a change of the labels (like a translation).
+func (s *SelectFormField) draftValue() (string, bool) {
+	if s.selectField.items == nil {
+		return s.selectField.Selected, true
+	}
+	i := s.selectField.selectedItemIndex()
+	if i < 0 {
+		return "", true
+	}
+	return strconv.Itoa(i), true
+}
+
+func (s *SelectFormField) restoreDraftValue(v string) {
+	i, err := strconv.Atoi(v)
+	switch {
+	case s.selectField.items == nil || v == "":
+		s.SetSelected(v)
+	case err == nil && i >= 0 && i < len(s.selectField.items):
+		s.selectField.selectedItem = i
+		s.SetSelected(s.selectField.items[i].Label)
+	default:
+		s.SetSelected(v) // a draft stored by label
+	}
+	s.Validate()
+	s.didChange()
 }
 
 // ValidationError returns the underlying validation error.
@@ -79,6 +197,7 @@ func (s *SelectFormField) ValidationError() error {
 
 // Validate validates the field.
 func (s *SelectFormField) Validate() error {
+	s.validationPending = false
 	if s.Validator != nil {
 		s.ExtendBaseFormField(s)
 		err := s.Validator(s.selectField.Selected)
@@ -91,11 +210,15 @@ func (s *SelectFormField) Validate() error {
 	return nil
 }
 
+func (s *SelectFormField) focusTarget() fyne.Focusable {
+	return s.selectField
+}
+
 func (s *SelectFormField) setupSelectField() {
 	s.selectField = NewSelectField(s.Options, nil)
 	s.selectField.Selected = s.initialValue
 	s.selectField.OnChanged = func(text string) {
-		if s.Validator != nil {
+		if s.Validator != nil && s.shouldRunValidator() {
 			if err := s.Validator(text); s.validationError != err {
 				s.validationError = err
 				s.Refresh()
@@ -109,7 +232,15 @@ func (s *SelectFormField) setupSelectField() {
 			s.Refresh()
 		}
 	}
-	s.selectField.onFocusChanged = func(bool) {
+	s.selectField.keyHandler = &s.BaseFormField
+	s.selectField.onFocusChanged = func(focused bool) {
+		if !focused {
+			s.blurred = true
+			if old := s.validationError; s.validateOnBlur() && s.Validate() != old {
+				// notify the form about the validation change.
+				s.didChange()
+			}
+		}
 		s.Refresh()
 	}
 	s.selectField.onHoverChanged = func(bool) {
@@ -7,6 +7,7 @@ import (
 	"fyne.io/fyne/v2/driver/desktop"
 	"fyne.io/fyne/v2/test"
 	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -145,3 +146,143 @@ func TestSelectFormField_DisabledTryHover(t *testing.T) {
 	r.labelBg.MouseOut()
 	test.AssertImageMatches(t, "select_form_field/disabled.png", w.Canvas().Capture())
 }
+
+func TestSelectFormField_Values(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	options := []SelectOption{
+		{Value: 1, Label: "Apple", Group: "Fruits"},
+		{Value: 2, Label: "Banana", Group: "Fruits", Disabled: true},
+		{Value: 3, Label: "Carrot", Group: "Vegetables"},
+	}
+	sf := NewSelectFormFieldWithValues("Food", 1, options)
+	var saved interface{}
+	sf.OnValueSaved = func(v interface{}) { saved = v }
+
+	w := test.NewWindow(sf)
+	w.Resize(fyne.NewSize(150, 80))
+	defer w.Close()
+
+	assert.Equal(t, "Apple", sf.Selected())
+	assert.Equal(t, 1, sf.SelectedValue())
+
+	sf.selectField.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight})
+	assert.Equal(t, 3, sf.SelectedValue())
+	sf.selectField.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight})
+	assert.Equal(t, 1, sf.SelectedValue())
+	sf.selectField.TypedKey(&fyne.KeyEvent{Name: fyne.KeyLeft})
+	assert.Equal(t, 3, sf.SelectedValue())
+
+	sf.SetSelectOptions([]SelectOption{
+		{Value: 1, Label: "Manzana"},
+		{Value: 3, Label: "Zanahoria"},
+	})
+	assert.Equal(t, "Zanahoria", sf.Selected())
+	assert.Equal(t, []string{"Manzana", "Zanahoria"}, sf.Options)
+	sf.Save()
+	assert.Equal(t, 3, saved)
+
+	sf.Reset()
+	assert.Equal(t, "Manzana", sf.Selected())
+
+	sf.SetSelectedValue(42)
+	assert.Nil(t, sf.SelectedValue())
+	assert.Equal(t, "", sf.Selected())
+}
+
+func TestSelectFormField_ValuesDropdown(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	sf := NewSelectFormFieldWithValues("Food", nil, []SelectOption{
+		{Value: "a", Label: "Apple", Group: "Fruits"},
+		{Value: "b", Label: "Banana", Group: "Fruits", Disabled: true},
+		{Value: "c", Label: "Carrot", Group: "Vegetables"},
+	})
+	changed := ""
+	sf.OnChanged = func(s string) { changed = s }
+
+	w := test.NewWindow(sf)
+	w.Resize(fyne.NewSize(150, 80))
+	defer w.Close()
+
+	test.Tap(sf.selectField)
+	assert.NotNil(t, sf.selectField.popUp)
+	list := sf.selectField.popUp.Content.(*fyne.Container)
+	assert.Len(t, list.Objects, 5) // 2 group headers and 3 options
+	assert.Equal(t, "Fruits", list.Objects[0].(*widget.Label).Text)
+	banana := list.Objects[2].(*widget.Button)
+	assert.True(t, banana.Disabled())
+	test.Tap(banana)
+	assert.Nil(t, sf.SelectedValue())
+
+	test.Tap(list.Objects[4].(*widget.Button))
+	assert.Equal(t, "c", sf.SelectedValue())
+	assert.Equal(t, "Carrot", changed)
+	assert.Nil(t, sf.selectField.popUp)
+}
+
+func TestSelectFormField_DuplicateLabels(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	sf := NewSelectFormFieldWithValues("Food", "veg-other", []SelectOption{
+		{Value: "fruit-apple", Label: "Apple", Group: "Fruits"},
+		{Value: "fruit-other", Label: "Other", Group: "Fruits"},
+		{Value: "veg-other", Label: "Other", Group: "Vegetables"},
+	})
+	var saved interface{}
+	sf.OnValueSaved = func(v interface{}) { saved = v }
+
+	w := test.NewWindow(sf)
+	w.Resize(fyne.NewSize(150, 80))
+	defer w.Close()
+
+	assert.Equal(t, "Other", sf.Selected())
+	assert.Equal(t, "veg-other", sf.SelectedValue())
+
+	sf.selectField.TypedKey(&fyne.KeyEvent{Name: fyne.KeyLeft})
+	assert.Equal(t, "fruit-other", sf.SelectedValue())
+	sf.selectField.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight})
+	assert.Equal(t, "veg-other", sf.SelectedValue())
+
+	sf.SetSelectedValue("fruit-apple")
+	test.Tap(sf.selectField)
+	list := sf.selectField.popUp.Content.(*fyne.Container)
+	test.Tap(list.Objects[4].(*widget.Button)) // "Other" in Vegetables
+	assert.Equal(t, "veg-other", sf.SelectedValue())
+	sf.Save()
+	assert.Equal(t, "veg-other", saved)
+
+	sf.SetSelectOptions([]SelectOption{
+		{Value: "fruit-other", Label: "Otra", Group: "Frutas"},
+		{Value: "veg-other", Label: "Otra", Group: "Verduras"},
+	})
+	assert.Equal(t, "veg-other", sf.SelectedValue())
+}
+
+func TestSelectFormField_DraftValue(t *testing.T) {
+	sf := NewSelectFormFieldWithValues("Food", "veg-other", []SelectOption{
+		{Value: "fruit-other", Label: "Other", Group: "Fruits"},
+		{Value: "veg-other", Label: "Other", Group: "Vegetables"},
+	})
+	w := test.NewWindow(sf)
+	defer w.Close()
+
+	v, ok := sf.draftValue()
+	assert.True(t, ok)
+	sf.SetSelectedValue("fruit-other")
+	sf.restoreDraftValue(v)
+	assert.Equal(t, "veg-other", sf.SelectedValue())
+
+	// the labels can change between sessions.
+	sf.SetSelectOptions([]SelectOption{
+		{Value: "fruit-other", Label: "Otra", Group: "Frutas"},
+		{Value: "veg-other", Label: "Otra", Group: "Verduras"},
+	})
+	sf.SetSelectedValue(nil)
+	sf.restoreDraftValue(v)
+	assert.Equal(t, "veg-other", sf.SelectedValue())
+	assert.Equal(t, "Otra", sf.Selected())
+
+	sf.SetSelectedValue(nil)
+	v, _ = sf.draftValue()
+	sf.SetSelectedValue("veg-other")
+	sf.restoreDraftValue(v)
+	assert.Nil(t, sf.SelectedValue())
+}
@@ -0,0 +1,602 @@
+package swid
+
+import (
+	"math"
+	"strconv"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// SliderFormField defines a form field to choose a number, or a range of
+// numbers, with a slider. The value is shown next to the slider.
+type SliderFormField struct {
+	BaseFormField
+
+	Min  float64
+	Max  float64
+	Step float64
+	// FormatValue formats the value shown next to the slider. By default
+	// the value is shown with the decimals of Step.
+	FormatValue func(v float64) string
+	// Validator checks the value of a slider created with
+	// NewSliderFormField.
+	Validator func(v float64) error
+	// RangeValidator checks the range of a slider created with
+	// NewRangeSliderFormField.
+	RangeValidator func(low, high float64) error
+
+	OnChanged func(low, high float64) `json:"-"`
+	OnSaved   func(low, high float64)
+
+	field       *sliderField
+	isRange     bool
+	low         float64
+	high        float64
+	initialLow  float64
+	initialHigh float64
+	isRendered  bool // TODO remove when Fyne has a way to check if the widget has been renderered or not
+}
+
+// NewSliderFormField creates a new slider form field with a single value.
+// The callbacks receive the value as high (low is always Min).
+func NewSliderFormField(label string, min, max, initialValue float64) *SliderFormField {
+	s := newSliderFormField(label, min, max, false)
+	s.initialLow, s.initialHigh = min, initialValue
+	s.low, s.high = s.clamp(min, initialValue)
+	return s
+}
+
+// NewRangeSliderFormField creates a new slider form field with two thumbs
+// to choose a range.
+func NewRangeSliderFormField(label string, min, max, initialLow, initialHigh float64) *SliderFormField {
+	s := newSliderFormField(label, min, max, true)
+	s.initialLow, s.initialHigh = initialLow, initialHigh
+	s.low, s.high = s.clamp(initialLow, initialHigh)
+	return s
+}
+
+func newSliderFormField(label string, min, max float64, isRange bool) *SliderFormField {
+	s := &SliderFormField{Min: min, Max: max, Step: 1, isRange: isRange}
+	s.ExtendBaseFormField(s)
+	s.Label = label
+	s.field = newSliderField(s)
+	s.field.keyHandler = &s.BaseFormField
+	return s
+}
+
+// ===============================================================
+// Methods
+// ===============================================================
+
+// Value returns the value of a single value slider (the high value of a
+// range slider).
+func (s *SliderFormField) Value() float64 {
+	return s.high
+}
+
+// SetValue sets the value of a single value slider.
+func (s *SliderFormField) SetValue(v float64) {
+	s.SetRange(s.low, v)
+}
+
+// Range returns the low and high values.
+func (s *SliderFormField) Range() (low, high float64) {
+	return s.low, s.high
+}
+
+// SetRange sets the low and high values of a range slider.
+func (s *SliderFormField) SetRange(low, high float64) {
+	s.low, s.high = s.clamp(low, high)
+	s.Refresh() // refresh the whole widget
+}
+
+// Reset resets the field to the initial value.
+func (s *SliderFormField) Reset() {
+	s.resetDirty()
+	s.SetRange(s.initialLow, s.initialHigh)
+	s.validationError = nil
+	if s.hasValidator() {
+		s.validationError = s.validate()
+	}
+	s.Refresh()
+	s.didChange()
+}
+
+// Save triggers the OnSaved callback.
+func (s *SliderFormField) Save() {
+	if s.OnSaved != nil {
+		s.OnSaved(s.low, s.high)
+	}
+}
+
+func (s *SliderFormField) draftValue() (string, bool) {
+	return strconv.FormatFloat(s.low, 'f', -1, 64) + ":" +
+		strconv.FormatFloat(s.high, 'f', -1, 64), true
+}
+
+func (s *SliderFormField) restoreDraftValue(v string) {
+	for i := 0; i < len(v); i++ {
+		if v[i] != ':' {
+			continue
+		}
+		low, err1 := strconv.ParseFloat(v[:i], 64)
+		high, err2 := strconv.ParseFloat(v[i+1:], 64)
+		if err1 != nil || err2 != nil {
+			return
+		}
+		s.SetRange(low, high)
+		s.Validate()
+		s.didChange()
+		return
+	}
+}
+
+// ValidationError returns the underlying validation error.
+func (s *SliderFormField) ValidationError() error {
+	if s.hasValidator() {
+		// TODO remove when Fyne has a way to check if the widget has been renderered or not
+		// means that this was called before CreateRenderer so create it by refreshing.
+		if !s.isRendered {
+			s.Refresh()
+		}
+		return s.validationError
+	}
+	return nil
+}
+
+// Validate validates the field.
+func (s *SliderFormField) Validate() error {
+	s.validationPending = false
+	if s.hasValidator() {
+		err := s.validate()
+		if s.validationError != err {
+			s.validationError = err
+			s.Refresh()
+		}
+		return s.validationError
+	}
+	return nil
+}
+
+func (s *SliderFormField) hasValidator() bool {
+	if s.isRange {
+		return s.RangeValidator != nil
+	}
+	return s.Validator != nil
+}
+
+func (s *SliderFormField) validate() error {
+	if s.isRange {
+		return s.RangeValidator(s.low, s.high)
+	}
+	return s.Validator(s.high)
+}
+
+// moveThumb sets the value of a thumb (0 is the low one) changed by the
+// user.
+func (s *SliderFormField) moveThumb(thumb int, v float64) {
+	if s.Disabled() {
+		return
+	}
+	low, high := s.low, s.high
+	if thumb == 0 {
+		low = math.Min(v, high)
+	} else {
+		high = v
+		if s.isRange {
+			high = math.Max(v, low)
+		}
+	}
+	low, high = s.clamp(low, high)
+	if low == s.low && high == s.high {
+		return
+	}
+	s.low, s.high = low, high
+	s.dirty = true
+	if s.hasValidator() && s.shouldRunValidator() {
+		s.validationError = s.validate()
+	}
+	if s.OnChanged != nil {
+		s.OnChanged(s.low, s.high)
+	}
+	s.didChange()
+	s.Refresh()
+}
+
+// clamp returns the values inside the bounds and rounded to the step.
+func (s *SliderFormField) clamp(low, high float64) (float64, float64) {
+	if !s.isRange {
+		low = s.Min
+	}
+	low, high = s.snap(low), s.snap(high)
+	if s.isRange && low > high {
+		low, high = high, low
+	}
+	return low, high
+}
+
+func (s *SliderFormField) snap(v float64) float64 {
+	if s.Step > 0 {
+		v = s.Min + math.Round((v-s.Min)/s.Step)*s.Step
+		// avoid float noise like 0.30000000000000004
+		v, _ = strconv.ParseFloat(strconv.FormatFloat(v, 'f', stepDecimals(s.Step)+6, 64), 64)
+	}
+	return math.Max(s.Min, math.Min(s.Max, v))
+}
+
+// valueText returns the text shown next to the slider.
+func (s *SliderFormField) valueText(low, high float64) string {
+	if s.isRange {
+		return s.formatValue(low) + " – " + s.formatValue(high)
+	}
+	return s.formatValue(high)
+}
+
+func (s *SliderFormField) formatValue(v float64) string {
+	if s.FormatValue != nil {
+		return s.FormatValue(v)
+	}
+	return strconv.FormatFloat(v, 'f', stepDecimals(s.Step), 64)
+}
+
+func (s *SliderFormField) focusChanged(focused bool) {
+	if !focused {
+		s.blurred = true
+		if old := s.validationError; s.validateOnBlur() && s.Validate() != old {
+			// notify the form about the validation change.
+			s.didChange()
+		}
+	}
+	s.Refresh()
+}
+
+func (s *SliderFormField) focusTarget() fyne.Focusable {
+	return s.field
+}
+
+// ===============================================================
+// Renderer
+// ===============================================================
+
+// CreateRenderer implements fyne.Widget.
+func (s *SliderFormField) CreateRenderer() fyne.WidgetRenderer {
+	s.ExtendBaseFormField(s)
+
+	if s.hasValidator() {
+		s.validationError = s.validate()
+	}
+
+	isFieldEmpty := func() bool {
+		return false // the label is always stacked above the slider.
+	}
+
+	isFieldFocused := func() bool {
+		return s.field.focused
+	}
+
+	updateInternalField := func() {
+		s.field.Refresh()
+	}
+
+	r := s.createPristineRenderer(
+		s.Label, s.Hint, s.field,
+		isFieldEmpty, isFieldFocused,
+		updateInternalField,
+	)
+
+	s.isRendered = true // TODO remove when Fyne has a way to check if the widget has been renderered or not
+
+	return r
+}
+
+// ===============================================================
+// Slider field
+// ===============================================================
+
+// sliderField is the internal widget of a SliderFormField. It shows the
+// track, the thumbs and the value.
+type sliderField struct {
+	widget.BaseWidget
+	field *SliderFormField
+
+	// active is the thumb moved with the keyboard (0 is the low one).
+	active   int
+	dragging int
+
+	hovered bool
+	focused bool
+
+	fieldKeys
+}
+
+func newSliderField(field *SliderFormField) *sliderField {
+	s := &sliderField{field: field, active: 1, dragging: -1}
+	s.ExtendBaseWidget(s)
+	return s
+}
+
+// trackBounds returns the x positions of the start and the end of the
+// track.
+func (s *sliderField) trackBounds() (start, end float32) {
+	pad := theme.Padding()
+	thumb := sliderThumbSize()
+	start = pad*2 + thumb/2
+	end = s.Size().Width - pad*3 - s.labelWidth() - thumb/2
+	return start, fyne.Max(start, end)
+}
+
+// labelWidth returns the width of the value text, measured with the
+// bounds so it does not change while the value changes.
+func (s *sliderField) labelWidth() float32 {
+	f := s.field
+	size := theme.TextSize()
+	return fyne.Max(fyne.MeasureText(f.valueText(f.Min, f.Min), size, fyne.TextStyle{}).Width,
+		fyne.MeasureText(f.valueText(f.Max, f.Max), size, fyne.TextStyle{}).Width)
+}
+
+func (s *sliderField) valueToX(v float64) float32 {
+	start, end := s.trackBounds()
+	f := s.field
+	if f.Max <= f.Min {
+		return start
+	}
+	return start + float32((v-f.Min)/(f.Max-f.Min))*(end-start)
+}
+
+func (s *sliderField) xToValue(x float32) float64 {
+	start, end := s.trackBounds()
+	f := s.field
+	if end <= start {
+		return f.Min
+	}
+	ratio := math.Max(0, math.Min(1, float64((x-start)/(end-start))))
+	return f.Min + ratio*(f.Max-f.Min)
+}
+
+// nearestThumb returns the thumb closer to the x position.
+func (s *sliderField) nearestThumb(x float32) int {
+	if !s.field.isRange {
+		return 1
+	}
+	lowX, highX := s.valueToX(s.field.low), s.valueToX(s.field.high)
+	if x < lowX || (x-lowX) < (highX-x) {
+		return 0
+	}
+	if lowX == highX && x < lowX {
+		return 0
+	}
+	return 1
+}
+
+func (s *sliderField) requestFocus() {
+	if c := fyne.CurrentApp().Driver().CanvasForObject(s); c != nil && !s.field.Disabled() {
+		c.Focus(s)
+	}
+}
+
+// Cursor implements desktop.Cursorable.
+func (s *sliderField) Cursor() desktop.Cursor {
+	return desktop.DefaultCursor
+}
+
+// MouseIn implements desktop.Hoverable.
+func (s *sliderField) MouseIn(*desktop.MouseEvent) {
+	s.hovered = true
+	s.Refresh()
+}
+
+// MouseMoved implements desktop.Hoverable.
+func (s *sliderField) MouseMoved(*desktop.MouseEvent) {}
+
+// MouseOut implements desktop.Hoverable.
+func (s *sliderField) MouseOut() {
+	s.hovered = false
+	s.Refresh()
+}
+
+// Tapped implements fyne.Tappable.
+func (s *sliderField) Tapped(ev *fyne.PointEvent) {
+	if s.field.Disabled() {
+		return
+	}
+	s.requestFocus()
+	s.active = s.nearestThumb(ev.Position.X)
+	s.field.moveThumb(s.active, s.xToValue(ev.Position.X))
+}
+
+// Dragged implements fyne.Draggable.
+func (s *sliderField) Dragged(ev *fyne.DragEvent) {
+	if s.field.Disabled() {
+		return
+	}
+	if s.dragging < 0 {
+		s.requestFocus()
+		s.dragging = s.nearestThumb(ev.Position.X)
+		s.active = s.dragging
+	}
+	s.field.moveThumb(s.dragging, s.xToValue(ev.Position.X))
+}
+
+// DragEnd implements fyne.Draggable.
+func (s *sliderField) DragEnd() {
+	s.dragging = -1
+}
+
+// FocusGained implements fyne.Focusable.
+func (s *sliderField) FocusGained() {
+	s.focused = true
+	s.field.focusChanged(true)
+}
+
+// FocusLost implements fyne.Focusable.
+func (s *sliderField) FocusLost() {
+	s.focused = false
+	s.field.focusChanged(false)
+}
+
+// TypedRune implements fyne.Focusable. The space switches the thumb moved
+// with the keyboard in a range slider.
+func (s *sliderField) TypedRune(r rune) {
+	if r == ' ' && s.field.isRange {
+		s.active = 1 - s.active
+		s.Refresh()
+	}
+}
+
+// TypedKey implements fyne.Focusable.
+func (s *sliderField) TypedKey(key *fyne.KeyEvent) {
+	if s.typedNavigationKey(key.Name) {
+		return
+	}
+	f := s.field
+	step := f.Step
+	if step <= 0 {
+		step = (f.Max - f.Min) / 100
+	}
+	v := f.high
+	if s.active == 0 {
+		v = f.low
+	}
+	switch key.Name {
+	case fyne.KeyLeft, fyne.KeyDown:
+		f.moveThumb(s.active, v-step)
+	case fyne.KeyRight, fyne.KeyUp:
+		f.moveThumb(s.active, v+step)
+	case fyne.KeyHome:
+		f.moveThumb(s.active, f.Min)
+	case fyne.KeyEnd:
+		f.moveThumb(s.active, f.Max)
+	}
+}
+
+func (s *sliderField) CreateRenderer() fyne.WidgetRenderer {
+	s.ExtendBaseWidget(s)
+	r := &sliderFieldRenderer{
+		track:  canvas.NewRectangle(theme.ShadowColor()),
+		active: canvas.NewRectangle(theme.PrimaryColor()),
+		focus:  canvas.NewCircle(theme.FocusColor()),
+		label:  canvas.NewText("", theme.ForegroundColor()),
+		widget: s,
+	}
+	r.thumbs = []*canvas.Circle{canvas.NewCircle(theme.PrimaryColor()), canvas.NewCircle(theme.PrimaryColor())}
+	r.Refresh()
+	return r
+}
+
+type sliderFieldRenderer struct {
+	track   *canvas.Rectangle
+	active  *canvas.Rectangle
+	focus   *canvas.Circle
+	thumbs  []*canvas.Circle
+	label   *canvas.Text
+	widget  *sliderField
+	objects []fyne.CanvasObject
+}
+
+func (r *sliderFieldRenderer) Destroy() {}
+
+func (r *sliderFieldRenderer) Layout(size fyne.Size) {
+	s := r.widget
+	f := s.field
+	pad := theme.Padding()
+	thumb := sliderThumbSize()
+	trackHeight := pad
+	start, end := s.trackBounds()
+	centerY := size.Height / 2
+
+	r.track.Move(fyne.NewPos(start, centerY-trackHeight/2))
+	r.track.Resize(fyne.NewSize(end-start, trackHeight))
+
+	lowX, highX := s.valueToX(f.low), s.valueToX(f.high)
+	r.active.Move(fyne.NewPos(lowX, centerY-trackHeight/2))
+	r.active.Resize(fyne.NewSize(highX-lowX, trackHeight))
+
+	for i, x := range []float32{lowX, highX} {
+		r.thumbs[i].Move(fyne.NewPos(x-thumb/2, centerY-thumb/2))
+		r.thumbs[i].Resize(fyne.NewSize(thumb, thumb))
+	}
+	focusX := highX
+	if s.active == 0 {
+		focusX = lowX
+	}
+	r.focus.Move(fyne.NewPos(focusX-thumb/2-pad, centerY-thumb/2-pad))
+	r.focus.Resize(fyne.NewSize(thumb+pad*2, thumb+pad*2))
+
+	labelMin := r.label.MinSize()
+	r.label.Move(fyne.NewPos(size.Width-pad*2-s.labelWidth(), centerY-labelMin.Height/2))
+	r.label.Resize(fyne.NewSize(s.labelWidth(), labelMin.Height))
+}
+
+func (r *sliderFieldRenderer) MinSize() fyne.Size {
+	pad := theme.Padding()
+	thumb := sliderThumbSize()
+	textHeight := fyne.MeasureText("M", theme.TextSize(), fyne.TextStyle{}).Height
+	return fyne.NewSize(pad*5+thumb*4+r.widget.labelWidth(),
+		fyne.Max(textHeight, thumb)+pad*4)
+}
+
+func (r *sliderFieldRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *sliderFieldRenderer) Refresh() {
+	s := r.widget
+	f := s.field
+	thumbColor := theme.PrimaryColor()
+	if f.Disabled() {
+		thumbColor = theme.DisabledColor()
+	}
+	r.track.FillColor = theme.ShadowColor()
+	r.active.FillColor = thumbColor
+	for _, t := range r.thumbs {
+		t.FillColor = thumbColor
+	}
+	r.focus.FillColor = theme.FocusColor()
+	if !s.focused {
+		r.focus.FillColor = theme.HoverColor()
+	}
+	r.focus.Hidden = f.Disabled() || (!s.focused && !s.hovered)
+
+	r.label.Text = f.valueText(f.low, f.high)
+	r.label.TextSize = theme.TextSize()
+	r.label.Color = theme.ForegroundColor()
+	if f.Disabled() {
+		r.label.Color = theme.DisabledColor()
+	}
+	r.label.Alignment = fyne.TextAlignTrailing
+
+	r.objects = []fyne.CanvasObject{r.track, r.active, r.focus}
+	if f.isRange {
+		r.objects = append(r.objects, r.thumbs[0])
+	}
+	r.objects = append(r.objects, r.thumbs[1], r.label)
+	r.Layout(s.Size())
+	canvas.Refresh(s)
+}
+
+// ===============================================================
+// Private helpers
+// ===============================================================
+
+func sliderThumbSize() float32 {
+	return theme.IconInlineSize() * 0.75
+}
+
+// stepDecimals returns the number of decimals of the step (like 2 for
+// 0.25).
+func stepDecimals(step float64) int {
+	if step <= 0 {
+		return 0
+	}
+	s := strconv.FormatFloat(step, 'f', -1, 64)
+	for i := 0; i < len(s); i++ {
+		if s[i] == '.' {
+			return len(s) - i - 1
+		}
+	}
+	return 0
+}
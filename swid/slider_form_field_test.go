@@ -0,0 +1,105 @@
+package swid
+
+import (
+	"errors"
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSliderFormField(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	s := NewSliderFormField("Volume", 0, 10, 3)
+	s.Step = 0.5
+	s.Validator = func(v float64) error {
+		if v > 8 {
+			return errors.New("too loud")
+		}
+		return nil
+	}
+	var changed, saved float64
+	s.OnChanged = func(_, high float64) { changed = high }
+	s.OnSaved = func(_, high float64) { saved = high }
+
+	form := NewForm(1, s)
+	w := test.NewWindow(form)
+	w.Resize(fyne.NewSize(300, 200))
+	defer w.Close()
+
+	assert.Equal(t, 3.0, s.Value())
+	assert.NoError(t, s.ValidationError())
+
+	s.field.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight})
+	assert.Equal(t, 3.5, s.Value())
+	assert.Equal(t, 3.5, changed)
+
+	s.field.TypedKey(&fyne.KeyEvent{Name: fyne.KeyEnd})
+	assert.Equal(t, 10.0, s.Value())
+	assert.EqualError(t, s.Validate(), "too loud")
+	assert.False(t, form.IsValid())
+
+	s.SetValue(4.3)
+	assert.Equal(t, 4.5, s.Value())
+	assert.Equal(t, "4.5", s.formatValue(s.Value()))
+	assert.NoError(t, s.Validate())
+	s.Save()
+	assert.Equal(t, 4.5, saved)
+
+	s.Reset()
+	assert.Equal(t, 3.0, s.Value())
+}
+
+func TestSliderFormField_Range(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	s := NewRangeSliderFormField("Price", 0, 100, 20, 80)
+	s.Step = 10
+	s.RangeValidator = func(low, high float64) error {
+		if high-low < 20 {
+			return errors.New("range too small")
+		}
+		return nil
+	}
+
+	w := test.NewWindow(s)
+	w.Resize(fyne.NewSize(300, 100))
+	defer w.Close()
+
+	low, high := s.Range()
+	assert.Equal(t, 20.0, low)
+	assert.Equal(t, 80.0, high)
+	assert.Equal(t, "20 – 80", s.valueText(low, high))
+
+	// the keys move the high thumb, the space switches to the low one
+	s.field.TypedKey(&fyne.KeyEvent{Name: fyne.KeyLeft})
+	s.field.TypedRune(' ')
+	s.field.TypedKey(&fyne.KeyEvent{Name: fyne.KeyRight})
+	low, high = s.Range()
+	assert.Equal(t, 30.0, low)
+	assert.Equal(t, 70.0, high)
+
+	// the low thumb can not pass the high one
+	s.moveThumb(0, 95)
+	low, high = s.Range()
+	assert.Equal(t, 70.0, low)
+	assert.Equal(t, 70.0, high)
+	assert.EqualError(t, s.Validate(), "range too small")
+
+	// a tap moves the nearest thumb
+	start, _ := s.field.trackBounds()
+	test.TapAt(s.field, fyne.NewPos(start, 10))
+	low, high = s.Range()
+	assert.Equal(t, 0.0, low)
+	assert.Equal(t, 70.0, high)
+	assert.NoError(t, s.Validate())
+
+	v, ok := s.draftValue()
+	assert.True(t, ok)
+	s.Reset()
+	s.restoreDraftValue(v)
+	low, high = s.Range()
+	assert.Equal(t, 0.0, low)
+	assert.Equal(t, 70.0, high)
+}
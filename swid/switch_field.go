@@ -0,0 +1,214 @@
+package swid
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// SwitchField defines an on/off switch widget.
+type SwitchField struct {
+	widget.DisableableWidget
+	Text    string
+	Checked bool
+
+	OnChanged func(bool) `json:"-"`
+
+	hovered        bool
+	focused        bool
+	onFocusChanged func(bool)
+
+	fieldKeys
+}
+
+// NewSwitchField creates a new switch field widget.
+func NewSwitchField(text string, changed func(bool)) *SwitchField {
+	s := &SwitchField{Text: text, OnChanged: changed}
+	s.ExtendBaseWidget(s)
+	return s
+}
+
+// ===============================================================
+// Methods
+// ===============================================================
+
+// SetChecked sets the state of the switch, calling OnChanged if it
+// changes.
+func (s *SwitchField) SetChecked(checked bool) {
+	if s.Checked == checked {
+		return
+	}
+	s.Checked = checked
+	if s.OnChanged != nil {
+		s.OnChanged(checked)
+	}
+	s.Refresh()
+}
+
+// Toggle changes the state of the switch.
+func (s *SwitchField) Toggle() {
+	if s.Disabled() {
+		return
+	}
+	s.SetChecked(!s.Checked)
+}
+
+// ===============================================================
+// Implementation
+// ===============================================================
+
+// Cursor implements desktop.Cursorable.
+func (s *SwitchField) Cursor() desktop.Cursor {
+	return desktop.DefaultCursor
+}
+
+// MouseIn implements desktop.Hoverable.
+func (s *SwitchField) MouseIn(*desktop.MouseEvent) {
+	s.hovered = true
+	s.Refresh()
+}
+
+// MouseMoved implements desktop.Hoverable.
+func (s *SwitchField) MouseMoved(*desktop.MouseEvent) {}
+
+// MouseOut implements desktop.Hoverable.
+func (s *SwitchField) MouseOut() {
+	s.hovered = false
+	s.Refresh()
+}
+
+// Tapped implements fyne.Tappable.
+func (s *SwitchField) Tapped(*fyne.PointEvent) {
+	if s.Disabled() {
+		return
+	}
+	if c := fyne.CurrentApp().Driver().CanvasForObject(s); c != nil {
+		c.Focus(s)
+	}
+	s.Toggle()
+}
+
+// FocusGained implements fyne.Focusable.
+func (s *SwitchField) FocusGained() {
+	s.focused = true
+	s.Refresh()
+	if s.onFocusChanged != nil {
+		s.onFocusChanged(true)
+	}
+}
+
+// FocusLost implements fyne.Focusable.
+func (s *SwitchField) FocusLost() {
+	s.focused = false
+	s.Refresh()
+	if s.onFocusChanged != nil {
+		s.onFocusChanged(false)
+	}
+}
+
+// TypedRune implements fyne.Focusable.
+func (s *SwitchField) TypedRune(r rune) {
+	if r == ' ' {
+		s.Toggle()
+	}
+}
+
+// TypedKey implements fyne.Focusable.
+func (s *SwitchField) TypedKey(key *fyne.KeyEvent) {
+	s.typedNavigationKey(key.Name)
+}
+
+// ===============================================================
+// Renderer
+// ===============================================================
+
+// CreateRenderer implements fyne.Widget.
+func (s *SwitchField) CreateRenderer() fyne.WidgetRenderer {
+	s.ExtendBaseWidget(s)
+	r := &switchFieldRenderer{
+		focus:  canvas.NewCircle(color.Transparent),
+		track:  canvas.NewRectangle(theme.ShadowColor()),
+		thumb:  canvas.NewCircle(theme.ButtonColor()),
+		label:  canvas.NewText(s.Text, theme.ForegroundColor()),
+		widget: s,
+	}
+	r.objects = []fyne.CanvasObject{r.focus, r.track, r.thumb, r.label}
+	r.Refresh()
+	return r
+}
+
+type switchFieldRenderer struct {
+	focus   *canvas.Circle
+	track   *canvas.Rectangle
+	thumb   *canvas.Circle
+	label   *canvas.Text
+	widget  *SwitchField
+	objects []fyne.CanvasObject
+}
+
+func (r *switchFieldRenderer) Destroy() {}
+
+func (r *switchFieldRenderer) Layout(size fyne.Size) {
+	pad := theme.Padding()
+	thumbSize := theme.IconInlineSize()
+	trackHeight := thumbSize / 2
+	r.track.Move(fyne.NewPos(pad*2, (size.Height-trackHeight)/2))
+	r.track.Resize(fyne.NewSize(thumbSize*2, trackHeight))
+
+	x := pad * 2
+	if r.widget.Checked {
+		x += thumbSize
+	}
+	y := (size.Height - thumbSize) / 2
+	r.thumb.Move(fyne.NewPos(x, y))
+	r.thumb.Resize(fyne.NewSize(thumbSize, thumbSize))
+	r.focus.Move(fyne.NewPos(x-pad, y-pad))
+	r.focus.Resize(fyne.NewSize(thumbSize+pad*2, thumbSize+pad*2))
+
+	labelX := pad*4 + thumbSize*2
+	r.label.Move(fyne.NewPos(labelX, (size.Height-r.label.MinSize().Height)/2))
+	r.label.Resize(fyne.NewSize(size.Width-labelX-pad, r.label.MinSize().Height))
+}
+
+func (r *switchFieldRenderer) MinSize() fyne.Size {
+	pad := theme.Padding()
+	thumbSize := theme.IconInlineSize()
+	labelMin := r.label.MinSize()
+	return fyne.NewSize(pad*5+thumbSize*2+labelMin.Width,
+		fyne.Max(thumbSize, labelMin.Height)+pad*2)
+}
+
+func (r *switchFieldRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *switchFieldRenderer) Refresh() {
+	s := r.widget
+	r.label.Text = s.Text
+	r.label.TextSize = theme.TextSize()
+	r.label.Color = theme.ForegroundColor()
+	switch {
+	case s.Disabled():
+		r.track.FillColor = theme.DisabledButtonColor()
+		r.thumb.FillColor = theme.DisabledColor()
+		r.label.Color = theme.DisabledColor()
+	case s.Checked:
+		r.track.FillColor = theme.FocusColor()
+		r.thumb.FillColor = theme.PrimaryColor()
+	default:
+		r.track.FillColor = theme.ShadowColor()
+		r.thumb.FillColor = theme.ButtonColor()
+	}
+	r.focus.FillColor = color.Transparent
+	if !s.Disabled() && s.focused {
+		r.focus.FillColor = theme.FocusColor()
+	} else if !s.Disabled() && s.hovered {
+		r.focus.FillColor = theme.HoverColor()
+	}
+	r.Layout(s.Size())
+	canvas.Refresh(s)
+}
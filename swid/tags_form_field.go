@@ -0,0 +1,509 @@
+package swid
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+	"github.com/fpabl0/sparky-go/svalid"
+)
+
+// TagsFormField defines a form field to type a list of tags. The typed
+// text is added as a tag with Enter or comma, and the tags are shown as
+// removable chips. Duplicated tags (ignoring the case) are not added.
+type TagsFormField struct {
+	BaseFormField
+
+	Placeholder string
+	// Suggestions are shown while typing: the ones that contain the text,
+	// ignoring the case, and that were not added yet.
+	Suggestions []string
+	// MaxTags is the maximum number of tags. It is not checked if it is 0.
+	MaxTags int
+	// TagValidator checks each tag before it is added (like svalid.Email).
+	TagValidator fyne.StringValidator
+	// Validator checks the tags (see svalid.MinSelected).
+	Validator func(tags []string) error
+
+	OnChanged func(tags []string) `json:"-"`
+	OnSaved   func(tags []string)
+
+	field        *tagsField
+	tags         []string
+	initialValue []string
+	// tagError is the error of the text that could not be added as a tag.
+	tagError    error
+	popUp       *widget.PopUp
+	suggestions *suggestionList
+	isRendered  bool // TODO remove when Fyne has a way to check if the widget has been renderered or not
+}
+
+// NewTagsFormField creates a new tags form field.
+func NewTagsFormField(label string, initialValue []string) *TagsFormField {
+	t := &TagsFormField{}
+	t.ExtendBaseFormField(t)
+	t.Label = label
+	t.initialValue = append([]string{}, initialValue...)
+	t.tags = append([]string{}, initialValue...)
+	t.field = newTagsField(t)
+	t.field.updateChips()
+	return t
+}
+
+// ===============================================================
+// Methods
+// ===============================================================
+
+// Tags returns the tags, in the order they were added.
+func (t *TagsFormField) Tags() []string {
+	return append([]string{}, t.tags...)
+}
+
+// SetTags sets the tags.
+func (t *TagsFormField) SetTags(tags []string) {
+	t.tags = append([]string{}, tags...)
+	t.field.updateChips()
+	t.Refresh() // refresh the whole widget
+}
+
+// AddTag adds the tag, after removing the spaces around it. It returns
+// the error of the TagValidator, or an error if the tag is duplicated or
+// the field already has MaxTags tags.
+func (t *TagsFormField) AddTag(tag string) error {
+	tag = strings.TrimSpace(tag)
+	if t.Disabled() || tag == "" {
+		return nil
+	}
+	if err := t.checkTag(tag); err != nil {
+		return err
+	}
+	t.tags = append(t.tags, tag)
+	t.tagsChanged()
+	return nil
+}
+
+// RemoveTag removes the tag.
+func (t *TagsFormField) RemoveTag(tag string) {
+	i := indexOfString(t.tags, tag)
+	if t.Disabled() || i < 0 {
+		return
+	}
+	t.tags = append(t.tags[:i:i], t.tags[i+1:]...)
+	t.tagsChanged()
+}
+
+// Reset resets the tags to the initial value.
+func (t *TagsFormField) Reset() {
+	t.resetDirty()
+	t.tagError = nil
+	t.field.input.SetText("")
+	t.SetTags(t.initialValue)
+	t.validationError = t.validate()
+	t.Refresh()
+	t.didChange()
+}
+
+// Save triggers the OnSaved callback.
+func (t *TagsFormField) Save() {
+	if t.OnSaved != nil {
+		t.OnSaved(t.Tags())
+	}
+}
+
+func (t *TagsFormField) draftValue() (string, bool) {
+	return strings.Join(t.tags, "\n"), true
+}
+
+func (t *TagsFormField) restoreDraftValue(v string) {
+	var tags []string
+	if v != "" {
+		tags = strings.Split(v, "\n")
+	}
+	t.SetTags(tags)
+	t.Validate()
+	t.didChange()
+}
+
+// ValidationError returns the underlying validation error.
+func (t *TagsFormField) ValidationError() error {
+	// TODO remove when Fyne has a way to check if the widget has been renderered or not
+	// means that this was called before CreateRenderer so create it by refreshing.
+	if !t.isRendered {
+		t.Refresh()
+	}
+	return t.validationError
+}
+
+// Validate validates the field. The text that could not be added as a tag
+// (like a duplicated one) makes the field invalid.
+func (t *TagsFormField) Validate() error {
+	t.validationPending = false
+	err := t.validate()
+	if t.validationError != err {
+		t.validationError = err
+		t.Refresh()
+	}
+	return t.validationError
+}
+
+// validate returns the error of the text that could not be added, and
+// then checks the number of tags and runs the Validator.
+func (t *TagsFormField) validate() error {
+	if t.tagError != nil {
+		return t.tagError
+	}
+	if t.MaxTags > 0 {
+		if err := svalid.MaxTags(t.MaxTags)(t.tags); err != nil {
+			return err
+		}
+	}
+	if t.Validator != nil {
+		return t.Validator(t.Tags())
+	}
+	return nil
+}
+
+// checkTag returns an error if the tag can not be added.
+func (t *TagsFormField) checkTag(tag string) error {
+	if t.TagValidator != nil {
+		if err := t.TagValidator(tag); err != nil {
+			return err
+		}
+	}
+	if err := svalid.NotDuplicated(t.tags)(tag); err != nil {
+		return err
+	}
+	if t.MaxTags > 0 {
+		return svalid.MaxTags(t.MaxTags)(append(t.Tags(), tag))
+	}
+	return nil
+}
+
+// addInput adds the typed text as a tag. If it can not be added, the text
+// is kept and the error is shown.
+func (t *TagsFormField) addInput() {
+	text := t.field.input.Text
+	if strings.TrimSpace(text) == "" {
+		return
+	}
+	t.hideSuggestions()
+	if err := t.AddTag(text); err != nil {
+		t.tagError = err
+		t.dirty = true
+		t.validationError = t.validate()
+		t.didChange()
+		t.Refresh()
+		return
+	}
+	t.field.input.SetText("")
+}
+
+func (t *TagsFormField) tagsChanged() {
+	t.dirty = true
+	t.tagError = nil
+	t.field.updateChips()
+	if t.shouldRunValidator() {
+		t.validationError = t.validate()
+	}
+	if t.OnChanged != nil {
+		t.OnChanged(t.Tags())
+	}
+	t.didChange()
+	t.Refresh()
+}
+
+// inputChanged clears the error of the text that could not be added, and
+// shows the suggestions for the new text.
+func (t *TagsFormField) inputChanged(text string) {
+	if t.tagError != nil {
+		t.tagError = nil
+		t.validationError = t.validate()
+		t.didChange()
+		t.Refresh()
+	}
+	if t.field.input.focused {
+		t.showSuggestions(text)
+	}
+}
+
+// typedKey overrides the BaseFormField method, so Enter adds the typed
+// text instead of moving to the next field.
+func (t *TagsFormField) typedKey(key fyne.KeyName, shift bool) bool {
+	if (key == fyne.KeyReturn || key == fyne.KeyEnter) && strings.TrimSpace(t.field.input.Text) != "" {
+		t.addInput()
+		return true
+	}
+	return t.BaseFormField.typedKey(key, shift)
+}
+
+// showSuggestions shows the suggestions that match the text.
+func (t *TagsFormField) showSuggestions(text string) {
+	if len(t.Suggestions) == 0 {
+		return
+	}
+	var items []string
+	if strings.TrimSpace(text) != "" {
+		for _, s := range matchOptions(t.Suggestions, text, OptionMatchSubstring) {
+			if svalid.NotDuplicated(t.tags)(s) == nil {
+				items = append(items, s)
+			}
+		}
+	}
+	if len(items) == 0 {
+		t.hideSuggestions()
+		return
+	}
+	if t.suggestions == nil {
+		t.suggestions = newSuggestionList(t)
+	}
+	t.suggestions.setItems(items, "")
+	t.popUp = showSuggestionPopUp(t.popUp, t.suggestions, t.field)
+}
+
+func (t *TagsFormField) suggestionInput() suggestionInput {
+	return t.field.input
+}
+
+func (t *TagsFormField) selectSuggestion(item string) {
+	t.hideSuggestions()
+	t.field.input.SetText(item)
+	t.addInput()
+}
+
+func (t *TagsFormField) hideSuggestions() {
+	if t.popUp != nil {
+		t.popUp.Hide()
+		t.popUp = nil
+	}
+}
+
+// cancelSearch implements suggestionOwner, the suggestions are not
+// searched asynchronously.
+func (t *TagsFormField) cancelSearch() {}
+
+func (t *TagsFormField) focusChanged(focused bool) {
+	if !focused {
+		t.hideSuggestions()
+		t.addInput()
+		t.blurred = true
+		if old := t.validationError; t.validateOnBlur() && t.Validate() != old {
+			// notify the form about the validation change.
+			t.didChange()
+		}
+	}
+	t.Refresh()
+}
+
+func (t *TagsFormField) focusTarget() fyne.Focusable {
+	return t.field.input
+}
+
+// ===============================================================
+// Renderer
+// ===============================================================
+
+// CreateRenderer implements fyne.Widget.
+func (t *TagsFormField) CreateRenderer() fyne.WidgetRenderer {
+	t.ExtendBaseFormField(t)
+
+	t.validationError = t.validate()
+
+	isFieldEmpty := func() bool {
+		return len(t.tags) == 0 && t.field.input.Text == ""
+	}
+
+	isFieldFocused := func() bool {
+		return t.field.input.focused
+	}
+
+	updateInternalField := func() {
+		for _, c := range t.field.chips {
+			c.(*chip).setRemovable(!t.Disabled())
+		}
+		if t.Disabled() {
+			t.field.input.Disable()
+		} else {
+			t.field.input.Enable()
+		}
+		t.field.Refresh()
+	}
+
+	r := t.CreateBaseRenderer(
+		t.Label, t.Hint, t.field,
+		isFieldEmpty, isFieldFocused,
+		updateInternalField,
+	)
+
+	t.isRendered = true // TODO remove when Fyne has a way to check if the widget has been renderered or not
+
+	return r
+}
+
+// ===============================================================
+// Tags field
+// ===============================================================
+
+// tagsField is the internal widget of a TagsFormField. It shows the tags
+// as chips followed by the input of the next tag.
+type tagsField struct {
+	widget.BaseWidget
+	field *TagsFormField
+
+	chips []fyne.CanvasObject
+	input *TextField
+}
+
+func newTagsField(field *TagsFormField) *tagsField {
+	f := &tagsField{field: field}
+	f.ExtendBaseWidget(f)
+	f.input = NewTextField()
+	f.input.keyHandler = field
+	f.input.onTypedRune = f.typedRune
+	f.input.onTypedKey = f.typedKey
+	f.input.onTypedShortcut = f.typedShortcut
+	f.input.OnChanged = field.inputChanged
+	f.input.onFocusChanged = field.focusChanged
+	return f
+}
+
+// updateChips creates the chips of the tags.
+func (f *tagsField) updateChips() {
+	chips := make([]fyne.CanvasObject, 0, len(f.field.tags))
+	for _, tag := range f.field.tags {
+		tag := tag
+		c := newChip(tag, func() { f.field.RemoveTag(tag) })
+		c.removable = !f.field.Disabled()
+		chips = append(chips, c)
+	}
+	f.chips = chips
+}
+
+// typedRune adds the typed text as a tag when the comma is typed.
+func (f *tagsField) typedRune(r rune) bool {
+	if r != ',' {
+		return false
+	}
+	f.field.addInput()
+	return true
+}
+
+// typedKey removes the last tag with Backspace when the input is empty.
+func (f *tagsField) typedKey(key *fyne.KeyEvent) bool {
+	if key.Name == fyne.KeyBackspace && f.input.Text == "" && len(f.field.tags) > 0 {
+		f.field.RemoveTag(f.field.tags[len(f.field.tags)-1])
+		return true
+	}
+	return false
+}
+
+// typedShortcut adds each part of a pasted text with commas as a tag.
+// The parts that can not be added are kept in the input. The other
+// shortcuts are handled by the form.
+func (f *tagsField) typedShortcut(s fyne.Shortcut) bool {
+	paste, ok := s.(*fyne.ShortcutPaste)
+	if !ok {
+		return f.field.typedShortcut(s)
+	}
+	if f.field.Disabled() || !strings.Contains(paste.Clipboard.Content(), ",") {
+		return false
+	}
+	parts := strings.Split(f.input.Text+paste.Clipboard.Content(), ",")
+	rejected := make([]string, 0, len(parts))
+	var err error
+	for _, p := range parts {
+		if strings.TrimSpace(p) == "" {
+			continue
+		}
+		if e := f.field.AddTag(p); e != nil {
+			rejected = append(rejected, strings.TrimSpace(p))
+			err = e
+		}
+	}
+	f.input.SetText(strings.Join(rejected, ", "))
+	if err != nil {
+		f.field.tagError = err
+		f.field.dirty = true
+		f.field.validationError = f.field.validate()
+		f.field.didChange()
+		f.field.Refresh()
+	}
+	return true
+}
+
+func (f *tagsField) CreateRenderer() fyne.WidgetRenderer {
+	f.ExtendBaseWidget(f)
+	r := &tagsFieldRenderer{widget: f}
+	r.Refresh()
+	return r
+}
+
+type tagsFieldRenderer struct {
+	widget  *tagsField
+	objects []fyne.CanvasObject
+}
+
+func (r *tagsFieldRenderer) Destroy() {}
+
+func (r *tagsFieldRenderer) Layout(size fyne.Size) {
+	r.layout(size.Width)
+}
+
+// layout places the chips and the input after them, in a new row if the
+// input does not fit. It returns the height used.
+func (r *tagsFieldRenderer) layout(width float32) float32 {
+	pad := theme.Padding()
+	chips := r.widget.chips
+	input := r.widget.input
+	inputMin := input.MinSize()
+	if len(chips) == 0 {
+		input.Move(fyne.NewPos(0, 0))
+		input.Resize(fyne.NewSize(width, inputMin.Height))
+		return inputMin.Height
+	}
+
+	height := layoutChips(chips, fyne.NewPos(pad*2, pad), width-pad*4) + pad
+	x, y := float32(0), height
+	// the input follows the last chip if it fits in the row.
+	last := chips[len(chips)-1]
+	if end := last.Position().X + last.Size().Width; width-end >= fyne.Max(inputMin.Width, tagsInputMinWidth()) {
+		x = end
+		y = fyne.Max(0, last.Position().Y+(last.Size().Height-inputMin.Height)/2)
+	}
+	input.Move(fyne.NewPos(x, y))
+	input.Resize(fyne.NewSize(width-x, inputMin.Height))
+	return fyne.Max(height, y+inputMin.Height)
+}
+
+func (r *tagsFieldRenderer) MinSize() fyne.Size {
+	inputMin := r.widget.input.MinSize()
+	min := fyne.NewSize(fyne.Max(inputMin.Width, tagsInputMinWidth()), inputMin.Height)
+	if width := r.widget.Size().Width; width > 0 && len(r.widget.chips) > 0 {
+		// the chips wrap at the current width.
+		min.Height = r.layout(width)
+	}
+	return min
+}
+
+func (r *tagsFieldRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *tagsFieldRenderer) Refresh() {
+	f := r.widget
+	f.input.PlaceHolder = f.field.Placeholder
+	r.objects = append([]fyne.CanvasObject{}, f.chips...)
+	r.objects = append(r.objects, f.input)
+	f.input.Refresh()
+	r.Layout(f.Size())
+	canvas.Refresh(f)
+}
+
+// ===============================================================
+// Private helpers
+// ===============================================================
+
+func tagsInputMinWidth() float32 {
+	return theme.IconInlineSize() * 4
+}
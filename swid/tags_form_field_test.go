@@ -0,0 +1,160 @@
+package swid
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"fyne.io/fyne/v2/theme"
+	"github.com/fpabl0/sparky-go/svalid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagsFormField(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	tags := NewTagsFormField("Recipients", []string{"ana@example.com"})
+	tags.TagValidator = svalid.Email()
+	tags.MaxTags = 3
+	tags.Validator = svalid.MinSelected(1)
+	var changed, saved []string
+	tags.OnChanged = func(v []string) { changed = v }
+	tags.OnSaved = func(v []string) { saved = v }
+
+	form := NewForm(1, tags)
+	w := test.NewWindow(form)
+	w.Resize(fyne.NewSize(400, 300))
+	defer w.Close()
+
+	input := tags.field.input
+	w.Canvas().Focus(input)
+
+	test.Type(input, "bob@example.com,")
+	assert.Equal(t, []string{"ana@example.com", "bob@example.com"}, tags.Tags())
+	assert.Equal(t, changed, tags.Tags())
+	assert.Equal(t, "", input.Text)
+
+	// an invalid tag is kept in the input with the error
+	test.Type(input, "carl")
+	input.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	assert.Len(t, tags.Tags(), 2)
+	assert.Equal(t, "carl", input.Text)
+	assert.Error(t, tags.ValidationError())
+	assert.False(t, form.IsValid())
+
+	test.Type(input, "@example.com")
+	assert.NoError(t, tags.ValidationError())
+	input.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	assert.Equal(t, []string{"ana@example.com", "bob@example.com", "carl@example.com"}, tags.Tags())
+
+	// max tags
+	test.Type(input, "dan@example.com,")
+	assert.EqualError(t, tags.ValidationError(), "Add at most 3 tags")
+	assert.Len(t, tags.Tags(), 3)
+
+	// Backspace removes the text, and then the last tag
+	input.SetText("")
+	input.TypedKey(&fyne.KeyEvent{Name: fyne.KeyBackspace})
+	assert.Equal(t, []string{"ana@example.com", "bob@example.com"}, tags.Tags())
+	assert.True(t, form.IsValid())
+
+	// the chips remove their tag
+	test.Tap(tags.field.chips[0].(*chip).removeButton)
+	assert.Equal(t, []string{"bob@example.com"}, tags.Tags())
+
+	tags.Save()
+	assert.Equal(t, []string{"bob@example.com"}, saved)
+
+	tags.Reset()
+	assert.Equal(t, []string{"ana@example.com"}, tags.Tags())
+}
+
+func TestTagsFormField_Duplicated(t *testing.T) {
+	tags := NewTagsFormField("Tags", []string{"go"})
+	w := test.NewWindow(tags)
+	defer w.Close()
+
+	assert.EqualError(t, tags.AddTag(" Go "), "This value was already added")
+	assert.NoError(t, tags.AddTag(" fyne "))
+	assert.Equal(t, []string{"go", "fyne"}, tags.Tags())
+
+	// the pasted text is split by commas
+	input := tags.field.input
+	w.Canvas().Focus(input)
+	test.NewClipboard()
+	w.Clipboard().SetContent("ui, GO, desktop")
+	input.TypedShortcut(&fyne.ShortcutPaste{Clipboard: w.Clipboard()})
+	assert.Equal(t, []string{"go", "fyne", "ui", "desktop"}, tags.Tags())
+	assert.Equal(t, "GO", input.Text)
+	assert.EqualError(t, tags.Validate(), "This value was already added")
+}
+
+func TestTagsFormField_PasteRejected(t *testing.T) {
+	tags := NewTagsFormField("Emails", nil)
+	tags.TagValidator = svalid.Email()
+	w := test.NewWindow(tags)
+	defer w.Close()
+
+	input := tags.field.input
+	w.Canvas().Focus(input)
+	test.NewClipboard()
+	w.Clipboard().SetContent("bob@example.com, nope, bad")
+	input.TypedShortcut(&fyne.ShortcutPaste{Clipboard: w.Clipboard()})
+	assert.Equal(t, []string{"bob@example.com"}, tags.Tags())
+	// the rejected parts are kept with their commas.
+	assert.Equal(t, "nope, bad", input.Text)
+
+	input.SetText("")
+	test.Type(input, "ann@example.com,")
+	assert.Equal(t, []string{"bob@example.com", "ann@example.com"}, tags.Tags())
+	assert.Equal(t, "", input.Text)
+}
+
+func TestTagsFormField_Suggestions(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	tags := NewTagsFormField("Languages", []string{"Go"})
+	tags.Suggestions = []string{"Go", "Rust", "Ruby", "Python"}
+	w := test.NewWindow(tags)
+	w.Resize(fyne.NewSize(400, 300))
+	defer w.Close()
+
+	input := tags.field.input
+	w.Canvas().Focus(input)
+	test.Type(input, "o")
+	// the added tags are not suggested
+	assert.Equal(t, []string{"Python"}, tags.suggestions.items)
+
+	tags.suggestions.TypedRune('b')
+	assert.Nil(t, tags.popUp)
+	input.SetText("")
+
+	test.Type(input, "ru")
+	assert.Equal(t, []string{"Rust", "Ruby"}, tags.suggestions.items)
+	tags.suggestions.TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	tags.suggestions.TypedKey(&fyne.KeyEvent{Name: fyne.KeyDown})
+	tags.suggestions.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	assert.Equal(t, []string{"Go", "Ruby"}, tags.Tags())
+	assert.Equal(t, "", input.Text)
+	assert.Nil(t, tags.popUp)
+}
+
+func TestTagsFormField_DismissSuggestions(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	tags := NewTagsFormField("Languages", nil)
+	tags.Suggestions = []string{"Go", "Rust", "Ruby", "Python"}
+	w := test.NewWindow(tags)
+	w.Resize(fyne.NewSize(400, 300))
+	defer w.Close()
+
+	input := tags.field.input
+	w.Canvas().Focus(input)
+	test.Type(input, "r")
+	assert.True(t, tags.popUp.Visible())
+
+	// a tap outside of the list dismisses the pop up.
+	test.Tap(tags.popUp)
+	assert.False(t, tags.popUp.Visible())
+
+	test.Type(input, "u")
+	assert.True(t, tags.popUp.Visible())
+	assert.Equal(t, []string{"Rust", "Ruby"}, tags.suggestions.items)
+}
@@ -0,0 +1,292 @@
+package swid
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// NewTextAdornment creates a static text to be used as the Leading or
+// Trailing content of a TextFormField (like "$" or "kg").
+func NewTextAdornment(text string) fyne.CanvasObject {
+	return widget.NewLabel(text)
+}
+
+// NewIconAdornment creates an icon to be used as the Leading or Trailing
+// content of a TextFormField.
+func NewIconAdornment(res fyne.Resource) fyne.CanvasObject {
+	return widget.NewIcon(res)
+}
+
+// ===============================================================
+// Adorned text field
+// ===============================================================
+
+// adornedTextField is the internal widget of a TextFormField. It shows the
+// text field with its leading and trailing content, the clear button and
+// the password revealer. Without any of them, it only shows the text field.
+type adornedTextField struct {
+	widget.BaseWidget
+	field *TextFormField
+
+	clearButton  *adornmentButton
+	revealButton *adornmentButton
+}
+
+func newAdornedTextField(field *TextFormField) *adornedTextField {
+	a := &adornedTextField{field: field}
+	a.ExtendBaseWidget(a)
+	a.clearButton = newAdornmentButton(theme.CancelIcon(), func() {
+		field.SetText("")
+		a.focusTextField()
+	})
+	a.revealButton = newAdornmentButton(theme.VisibilityOffIcon(), func() {
+		field.textField.Password = !field.textField.Password
+		field.textField.Refresh()
+		a.Refresh()
+		a.focusTextField()
+	})
+	return a
+}
+
+// Cursor implements desktop.Cursorable.
+func (a *adornedTextField) Cursor() desktop.Cursor {
+	return a.field.textField.Cursor()
+}
+
+// Tapped implements fyne.Tappable.
+func (a *adornedTextField) Tapped(ev *fyne.PointEvent) {
+	a.focusTextField()
+	a.field.textField.Tapped(ev)
+}
+
+// Disable implements fyne.Disableable.
+func (a *adornedTextField) Disable() {
+	a.field.textField.Disable()
+}
+
+// Enable implements fyne.Disableable.
+func (a *adornedTextField) Enable() {
+	a.field.textField.Enable()
+}
+
+// Disabled implements fyne.Disableable.
+func (a *adornedTextField) Disabled() bool {
+	return a.field.textField.Disabled()
+}
+
+func (a *adornedTextField) focusTextField() {
+	if c := fyne.CurrentApp().Driver().CanvasForObject(a.field.textField); c != nil {
+		c.Focus(a.field.textField)
+	}
+}
+
+// leading returns the objects shown before the text.
+func (a *adornedTextField) leading() []fyne.CanvasObject {
+	f := a.field
+	if f.Leading == nil {
+		return f.extraLeading
+	}
+	return append([]fyne.CanvasObject{f.Leading}, f.extraLeading...)
+}
+
+// trailing returns the objects shown after the text.
+func (a *adornedTextField) trailing() []fyne.CanvasObject {
+	f := a.field
+	objects := make([]fyne.CanvasObject, 0, 4)
+	if f.Trailing != nil {
+		objects = append(objects, f.Trailing)
+	}
+	if f.ActionItem != nil {
+		objects = append(objects, f.ActionItem)
+	}
+	if f.ShowClearButton && f.textField.Text != "" && !f.Disabled() {
+		objects = append(objects, a.clearButton)
+	}
+	if f.isPasswordField {
+		objects = append(objects, a.revealButton)
+	}
+	return append(objects, f.extraTrailing...)
+}
+
+func (a *adornedTextField) leadingInset() float32 {
+	return adornmentsWidth(a.leading())
+}
+
+func (a *adornedTextField) CreateRenderer() fyne.WidgetRenderer {
+	a.ExtendBaseWidget(a)
+	r := &adornedTextFieldRenderer{
+		bg:     canvas.NewRectangle(theme.InputBackgroundColor()),
+		line:   canvas.NewRectangle(theme.ShadowColor()),
+		widget: a,
+	}
+	r.Refresh()
+	return r
+}
+
+type adornedTextFieldRenderer struct {
+	bg      *canvas.Rectangle
+	line    *canvas.Rectangle
+	widget  *adornedTextField
+	objects []fyne.CanvasObject
+
+	leading  []fyne.CanvasObject
+	trailing []fyne.CanvasObject
+}
+
+func (r *adornedTextFieldRenderer) Destroy() {}
+
+func (r *adornedTextFieldRenderer) Layout(size fyne.Size) {
+	textField := r.widget.field.textField
+	if len(r.leading) == 0 && len(r.trailing) == 0 {
+		textField.Move(fyne.NewPos(0, 0))
+		textField.Resize(size)
+		return
+	}
+	r.bg.Move(fyne.NewPos(0, theme.InputBorderSize()))
+	r.bg.Resize(size.Subtract(fyne.NewSize(0, theme.InputBorderSize()*2)))
+	r.line.Move(fyne.NewPos(0, size.Height-theme.InputBorderSize()))
+	r.line.Resize(fyne.NewSize(size.Width, theme.InputBorderSize()))
+
+	leadingWidth := adornmentsWidth(r.leading)
+	trailingWidth := adornmentsWidth(r.trailing)
+	layoutAdornments(r.leading, 0, size.Height)
+	layoutAdornments(r.trailing, size.Width-trailingWidth, size.Height)
+	textField.Move(fyne.NewPos(leadingWidth, 0))
+	textField.Resize(fyne.NewSize(size.Width-leadingWidth-trailingWidth, size.Height))
+}
+
+func (r *adornedTextFieldRenderer) MinSize() fyne.Size {
+	min := r.widget.field.textField.MinSize()
+	for _, o := range append(r.leading, r.trailing...) {
+		min.Height = fyne.Max(min.Height, o.MinSize().Height)
+	}
+	min.Width += adornmentsWidth(r.leading) + adornmentsWidth(r.trailing)
+	return min
+}
+
+func (r *adornedTextFieldRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *adornedTextFieldRenderer) Refresh() {
+	f := r.widget.field
+	r.leading = r.widget.leading()
+	r.trailing = r.widget.trailing()
+	r.objects = make([]fyne.CanvasObject, 0, len(r.leading)+len(r.trailing)+3)
+	if len(r.leading) > 0 || len(r.trailing) > 0 {
+		r.objects = append(r.objects, r.bg, r.line)
+	}
+	r.objects = append(r.objects, r.leading...)
+	r.objects = append(r.objects, f.textField)
+	r.objects = append(r.objects, r.trailing...)
+
+	if f.textField.Password {
+		r.widget.revealButton.setIcon(theme.VisibilityOffIcon())
+	} else {
+		r.widget.revealButton.setIcon(theme.VisibilityIcon())
+	}
+
+	r.bg.FillColor = theme.InputBackgroundColor()
+	// follow the line color of the text field.
+	r.line.FillColor = theme.ShadowColor()
+	if f.textField.focused {
+		r.line.FillColor = theme.PrimaryColor()
+	} else if f.textField.Validator != nil && f.isErrorVisible(false) {
+		r.line.FillColor = theme.ErrorColor()
+	}
+	r.bg.Refresh()
+	r.line.Refresh()
+	r.Layout(r.widget.Size())
+	canvas.Refresh(r.widget)
+}
+
+// adornmentsWidth returns the width used by the adornments, including
+// the padding around them.
+func adornmentsWidth(objects []fyne.CanvasObject) float32 {
+	if len(objects) == 0 {
+		return 0
+	}
+	width := theme.Padding()
+	for _, o := range objects {
+		width += o.MinSize().Width + theme.Padding()
+	}
+	return width
+}
+
+// layoutAdornments places the adornments in a row starting at x, centered
+// vertically.
+func layoutAdornments(objects []fyne.CanvasObject, x, height float32) {
+	x += theme.Padding()
+	for _, o := range objects {
+		min := o.MinSize()
+		o.Move(fyne.NewPos(x, (height-min.Height)/2))
+		o.Resize(min)
+		x += min.Width + theme.Padding()
+	}
+}
+
+// ===============================================================
+// Adornment button
+// ===============================================================
+
+// adornmentButton defines a small tappable icon shown inside a field.
+type adornmentButton struct {
+	widget.BaseWidget
+	icon     *widget.Icon
+	onTapped func()
+}
+
+func newAdornmentButton(res fyne.Resource, onTapped func()) *adornmentButton {
+	b := &adornmentButton{icon: widget.NewIcon(res), onTapped: onTapped}
+	b.ExtendBaseWidget(b)
+	return b
+}
+
+func (b *adornmentButton) setIcon(res fyne.Resource) {
+	if b.icon.Resource != res {
+		b.icon.SetResource(res)
+	}
+}
+
+// Cursor implements desktop.Cursorable.
+func (b *adornmentButton) Cursor() desktop.Cursor {
+	return desktop.DefaultCursor
+}
+
+// Tapped implements fyne.Tappable.
+func (b *adornmentButton) Tapped(*fyne.PointEvent) {
+	if b.onTapped != nil {
+		b.onTapped()
+	}
+}
+
+func (b *adornmentButton) CreateRenderer() fyne.WidgetRenderer {
+	b.ExtendBaseWidget(b)
+	return &adornmentButtonRenderer{icon: b.icon, objects: []fyne.CanvasObject{b.icon}}
+}
+
+type adornmentButtonRenderer struct {
+	icon    *widget.Icon
+	objects []fyne.CanvasObject
+}
+
+func (r *adornmentButtonRenderer) Destroy() {}
+
+func (r *adornmentButtonRenderer) Layout(size fyne.Size) {
+	r.icon.Resize(size)
+}
+
+func (r *adornmentButtonRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(theme.IconInlineSize(), theme.IconInlineSize())
+}
+
+func (r *adornmentButtonRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *adornmentButtonRenderer) Refresh() {
+	r.icon.Refresh()
+}
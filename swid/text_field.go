@@ -1,8 +1,6 @@
 package swid
 
 import (
-	"unicode"
-
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/widget"
 )
@@ -11,12 +9,29 @@ import (
 type TextField struct {
 	widget.Entry
 	MaxLength int
+	// OnInputRejected is called with the characters that were not accepted
+	// by the mask, the input restriction or MaxLength, when they are typed,
+	// pasted or set with SetText.
+	OnInputRejected func(rejected string)
 
 	mask        []rune
+	maskClasses map[rune]func(rune) bool
 	restriction RestrictInput
 
 	focused        bool
 	onFocusChanged func(bool)
+
+	onTypedShortcut func(fyne.Shortcut) bool
+	onTypedKey      func(*fyne.KeyEvent) bool
+	onTypedRune     func(rune) bool
+	// acceptRune replaces the input restriction when it is set.
+	acceptRune func(text string, r rune, pos int) bool
+
+	fieldKeys
+
+	// hidePasswordRevealer avoids the password revealer of the entry, when
+	// it is provided by the parent widget.
+	hidePasswordRevealer bool
 }
 
 // NewTextField creates a new text field.
@@ -52,6 +67,14 @@ func NewPasswordTextField() *TextField {
 //  9: Represents a numeric character (0-9)
 //  a: Represents an alpha character (A-Z,a-z)
 //  *: Represents an alphanumeric character (A-Z,a-z,0-9)
+//  \: Escapes the next character, so it is used as a literal (like \9)
+//  [ ]: Encloses an optional section, that is not required to complete
+//       the mask
+//  >: Converts the following characters to uppercase
+//  <: Converts the following characters to lowercase
+//  |: Disables the case conversion
+//
+// Custom placeholders can be defined with SetMaskClass.
 func NewMaskedTextField(mask, placeHolder string) *TextField {
 	t := &TextField{}
 	t.ExtendBaseWidget(t)
@@ -65,6 +88,17 @@ func NewMaskedTextField(mask, placeHolder string) *TextField {
 // Implementation
 // ===============================================================
 
+// CreateRenderer overrides widget.Entry method.
+func (t *TextField) CreateRenderer() fyne.WidgetRenderer {
+	t.ExtendBaseWidget(t)
+	if t.hidePasswordRevealer && t.Password && t.ActionItem == nil {
+		// the entry only adds its revealer when it creates the renderer.
+		t.Password = false
+		defer func() { t.Password = true }()
+	}
+	return t.Entry.CreateRenderer()
+}
+
 // MinSize implements fyne.CanvasObject.
 func (t *TextField) MinSize() fyne.Size {
 	t.ExtendBaseWidget(t)
@@ -89,46 +123,283 @@ func (t *TextField) FocusLost() {
 	}
 }
 
+// TypedShortcut overrides widget.Entry method.
+func (t *TextField) TypedShortcut(s fyne.Shortcut) {
+	if t.onTypedShortcut != nil && t.onTypedShortcut(s) {
+		return
+	}
+	if t.mask != nil && !t.Disabled() {
+		switch s.(type) {
+		case *fyne.ShortcutPaste, *fyne.ShortcutCut:
+			t.maskEdit(func() { t.Entry.TypedShortcut(s) })
+			return
+		}
+	}
+	if paste, ok := s.(*fyne.ShortcutPaste); ok && !t.Disabled() {
+		t.paste(paste)
+		return
+	}
+	t.Entry.TypedShortcut(s)
+}
+
+// paste pastes the clipboard content, filtering it with the same rules
+// used for the typed characters.
+func (t *TextField) paste(paste *fyne.ShortcutPaste) {
+	oldText := t.Text
+	content := []rune(paste.Clipboard.Content())
+	// let the entry replace the selection (if any) and then filter the
+	// pasted text, without notifying the intermediate text.
+	onChanged, validator := t.OnChanged, t.Validator
+	t.OnChanged, t.Validator = nil, nil
+	t.Entry.TypedShortcut(paste)
+
+	text := []rune(t.Text)
+	end := textPosFromRowCol(text, t.CursorRow, t.CursorColumn)
+	start := end - len(content)
+	if start < 0 {
+		start = 0
+	}
+	rest := append(append([]rune{}, text[:start]...), text[end:]...)
+	result, cursor, rejected := t.insertInput(rest, start, text[start:end])
+	t.Entry.SetText(string(result))
+	t.CursorRow, t.CursorColumn = rowColFromTextPos(result, cursor)
+	t.OnChanged, t.Validator = onChanged, validator
+	t.Validate()
+	t.Refresh()
+	t.reportRejected(rejected)
+	if t.Text != oldText && onChanged != nil {
+		onChanged(t.Text)
+	}
+}
+
+// filterText returns the text accepted by the mask, the input restriction
+// and MaxLength, as if it were typed in an empty field.
+func (t *TextField) filterText(text string) string {
+	result, _, rejected := t.insertInput(nil, 0, []rune(text))
+	t.reportRejected(rejected)
+	return string(result)
+}
+
+// insertInput returns the result of inserting the input into the text at
+// the position pos, the position after the inserted input and the rejected
+// characters.
+func (t *TextField) insertInput(text []rune, pos int, input []rune) (result []rune, cursor int, rejected []rune) {
+	if t.mask != nil {
+		m := t.textMask()
+		raw := m.raw(text)
+		_, slots, _ := m.format(raw)
+		start := slotsBefore(slots, pos)
+		raw, rawCursor, rejected, _ := m.edit(raw, start, start, input)
+		result, slots, _ = m.format(raw)
+		return result, maskTextPos(slots, rawCursor), rejected
+	}
+	result = append(result, text[:pos]...)
+	suffix := text[pos:]
+	for _, r := range input {
+		full := string(result) + string(suffix)
+		if (t.MaxLength > 0 && len(result)+len(suffix) >= t.MaxLength) ||
+			!t.accepts(full, r, len(result)) {
+			rejected = append(rejected, r)
+			continue
+		}
+		result = append(result, r)
+	}
+	cursor = len(result)
+	return append(result, suffix...), cursor, rejected
+}
+
+// RawText returns the characters typed in the mask placeholders, without
+// the mask literals (like "1234" for "12/34"). It returns the text if the
+// field has no mask.
+func (t *TextField) RawText() string {
+	if t.mask == nil {
+		return t.Text
+	}
+	return string(t.textMask().raw([]rune(t.Text)))
+}
+
+// MaskComplete returns true if all the required placeholders of the mask
+// have a value. It returns true if the field has no mask.
+func (t *TextField) MaskComplete() bool {
+	if t.mask == nil {
+		return true
+	}
+	m := t.textMask()
+	return m.complete(m.raw([]rune(t.Text)))
+}
+
+// SetMaskClass defines a custom mask placeholder that accepts the
+// characters for which accept returns true.
+func (t *TextField) SetMaskClass(placeholder rune, accept func(r rune) bool) {
+	if t.maskClasses == nil {
+		t.maskClasses = make(map[rune]func(rune) bool)
+	}
+	t.maskClasses[placeholder] = accept
+}
+
+func (t *TextField) textMask() *textMask {
+	return parseMask(t.mask, t.maskClasses)
+}
+
+// maskEdit runs an edit action of the entry and then formats again the
+// text with the mask, placing the cursor after the edited characters.
+func (t *TextField) maskEdit(action func()) {
+	m := t.textMask()
+	oldText := []rune(t.Text)
+	oldCursor := textPosFromRowCol(oldText, t.CursorRow, t.CursorColumn)
+	oldRaw := m.raw(oldText)
+	_, oldSlots, _ := m.format(oldRaw)
+
+	onChanged, validator := t.OnChanged, t.Validator
+	t.OnChanged, t.Validator = nil, nil
+	action()
+	newText := []rune(t.Text)
+
+	// find the edited range of the old text.
+	prefix := 0
+	for prefix < len(oldText) && prefix < len(newText) && oldText[prefix] == newText[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(oldText)-prefix && suffix < len(newText)-prefix &&
+		oldText[len(oldText)-1-suffix] == newText[len(newText)-1-suffix] {
+		suffix++
+	}
+	removedEnd := len(oldText) - suffix
+	input := newText[prefix : len(newText)-suffix]
+	start, end := slotsBefore(oldSlots, prefix), slotsBefore(oldSlots, removedEnd)
+	if start == end && len(input) == 0 && removedEnd > prefix {
+		// only literals were removed, so remove the character before them
+		// (backspace) or after them (delete).
+		if oldCursor > prefix {
+			if start > 0 {
+				start--
+			}
+		} else if end < len(oldRaw) {
+			end++
+		}
+	}
+
+	raw, rawCursor, rejected, ok := m.edit(oldRaw, start, end, input)
+	result, slots, _ := m.format(raw)
+	cursor := maskTextPos(slots, rawCursor)
+	if !ok {
+		result, cursor = oldText, oldCursor
+	}
+	t.Entry.SetText(string(result))
+	t.CursorRow, t.CursorColumn = rowColFromTextPos(result, cursor)
+	t.OnChanged, t.Validator = onChanged, validator
+	t.Validate()
+	t.Refresh()
+	t.reportRejected(rejected)
+	if t.Text != string(oldText) && onChanged != nil {
+		onChanged(t.Text)
+	}
+}
+
+// accepts returns true if the rune can be inserted in the text at pos.
+func (t *TextField) accepts(text string, r rune, pos int) bool {
+	if t.acceptRune != nil {
+		return t.acceptRune(text, r, pos)
+	}
+	return acceptChar(t.restriction, text, r, pos)
+}
+
+func (t *TextField) reportRejected(rejected []rune) {
+	if len(rejected) > 0 && t.OnInputRejected != nil {
+		t.OnInputRejected(string(rejected))
+	}
+}
+
+// SetText overrides widget.Entry method. The text is filtered with the
+// mask, the input restriction and MaxLength.
+func (t *TextField) SetText(text string) {
+	t.Entry.SetText(t.filterText(text))
+}
+
 // TypedRune overrides widget.Entry method.
 func (t *TextField) TypedRune(r rune) {
 	if t.Disabled() {
 		return
 	}
+	if t.onTypedRune != nil && t.onTypedRune(r) {
+		return
+	}
 	if t.mask != nil {
-		t.maskVerifyOnTypedRune(r, t.CursorColumn)
+		t.maskEdit(func() { t.Entry.TypedRune(r) })
 		return
 	}
 	if t.MaxLength > 0 && (len([]rune(t.Text))+1) > t.MaxLength {
+		t.reportRejected([]rune{r})
 		return
 	}
-	if acceptChar(t.restriction, t.Text, r, t.CursorColumn) {
+	if t.accepts(t.Text, r, t.CursorColumn) {
 		t.Entry.TypedRune(r)
+	} else {
+		t.reportRejected([]rune{r})
 	}
 }
 
-// maskVerifyOnTypedRune verifies mask when user type a rune.
-func (t *TextField) maskVerifyOnTypedRune(r rune, colPos int) {
-	totalLen := len([]rune(t.Text))
-	if (totalLen + 1) > len(t.mask) {
+// AcceptsTab overrides widget.Entry method.
+func (t *TextField) AcceptsTab() bool {
+	if t.MultiLine || t.keyHandler == nil {
+		return t.Entry.AcceptsTab()
+	}
+	return t.fieldKeys.AcceptsTab()
+}
+
+// KeyDown overrides widget.Entry method.
+func (t *TextField) KeyDown(key *fyne.KeyEvent) {
+	t.fieldKeys.KeyDown(key)
+	t.Entry.KeyDown(key)
+}
+
+// KeyUp overrides widget.Entry method.
+func (t *TextField) KeyUp(key *fyne.KeyEvent) {
+	t.fieldKeys.KeyUp(key)
+	t.Entry.KeyUp(key)
+}
+
+// TypedKey overrides widget.Entry method.
+func (t *TextField) TypedKey(key *fyne.KeyEvent) {
+	if !t.MultiLine && t.typedNavigationKey(key.Name) {
+		return
+	}
+	if t.onTypedKey != nil && t.onTypedKey(key) {
+		return
+	}
+	if t.mask != nil && !t.Disabled() && (key.Name == fyne.KeyBackspace || key.Name == fyne.KeyDelete) {
+		t.maskEdit(func() { t.Entry.TypedKey(key) })
 		return
 	}
+	t.Entry.TypedKey(key)
+}
 
-	i := colPos
-	for t.mask[i] != 'a' && t.mask[i] != '9' && t.mask[i] != '*' {
-		t.Entry.TypedRune(t.mask[i])
-		if t.mask[i] == r {
-			return
-		}
-		i++
-		totalLen++
-		if totalLen >= len(t.mask) {
-			return
+// textPosFromRowCol returns the index in text of the row and column.
+func textPosFromRowCol(text []rune, row, col int) int {
+	pos := 0
+	for row > 0 && pos < len(text) {
+		if text[pos] == '\n' {
+			row--
 		}
+		pos++
 	}
-	if (t.mask[i] == '9' && !unicode.IsDigit(r)) ||
-		(t.mask[i] == 'a' && !unicode.IsLetter(r)) ||
-		(t.mask[i] == '*' && !unicode.IsDigit(r) && !unicode.IsLetter(r)) {
-		return
+	pos += col
+	if pos > len(text) {
+		return len(text)
+	}
+	return pos
+}
+
+// rowColFromTextPos returns the row and column of the index in text.
+func rowColFromTextPos(text []rune, pos int) (row, col int) {
+	for i := 0; i < pos && i < len(text); i++ {
+		if text[i] == '\n' {
+			row++
+			col = 0
+		} else {
+			col++
+		}
 	}
-	t.Entry.TypedRune(r)
+	return row, col
 }
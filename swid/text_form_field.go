@@ -1,7 +1,11 @@
 package swid
 
 import (
+	"image/color"
+	"strconv"
+
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
 )
 
 // TextFormField defines a special text field for Forms.
@@ -14,12 +18,32 @@ type TextFormField struct {
 	Validator   fyne.StringValidator
 	// ActionItem is a small item which is displayed at the outer right of the entry (like a password revealer)
 	ActionItem fyne.CanvasObject
-	MaxLength  int
+	// Leading and Trailing are shown before and after the text (see
+	// NewTextAdornment and NewIconAdornment).
+	Leading  fyne.CanvasObject
+	Trailing fyne.CanvasObject
+	// ShowClearButton shows a button to clear the text when it is not empty.
+	ShowClearButton bool
+	MaxLength       int
+	// ShowCounter shows the number of characters (and MaxLength if it is
+	// set) at the end of the hint line.
+	ShowCounter bool
 
 	OnChanged func(s string)
 	OnSaved   func(s string)
+	// OnInputRejected is called with the characters that were not accepted
+	// by the mask, the input restriction or MaxLength.
+	OnInputRejected func(rejected string)
 
-	textField        *TextField
+	textField *TextField
+	adorned   *adornedTextField
+	// valueValidator is used instead of Validator by the fields built on
+	// top of TextFormField (like NumberFormField).
+	valueValidator fyne.StringValidator
+	// extraLeading are shown after the leading adornments and
+	// extraTrailing after the trailing ones.
+	extraLeading     []fyne.CanvasObject
+	extraTrailing    []fyne.CanvasObject
 	initialText      string
 	isPasswordField  bool
 	resetOverrideErr bool
@@ -48,6 +72,7 @@ func NewMultiLineTextField(label, initialText string) *TextFormField {
 func NewRestrictTextFormField(label, initialText string, input RestrictInput) *TextFormField {
 	t := NewTextFormField(label, initialText)
 	t.textField.restriction = input
+	t.filterInitialText()
 	return t
 }
 
@@ -60,15 +85,12 @@ func NewPasswordTextFormField(label, initialText string) *TextFormField {
 }
 
 // NewMaskedTextFormField creates a new text form field with a mask.
-// Mask definitions:
-//
-//  9: Represents a numeric character (0-9)
-//  a: Represents an alpha character (A-Z,a-z)
-//  *: Represents an alphanumeric character (A-Z,a-z,0-9)
+// See NewMaskedTextField for the mask definitions.
 func NewMaskedTextFormField(label, initialText, mask, placeHolder string) *TextFormField {
 	t := NewTextFormField(label, initialText)
 	t.Placeholder = placeHolder
 	t.textField.mask = []rune(mask)
+	t.filterInitialText()
 	return t
 }
 
@@ -81,18 +103,39 @@ func (t *TextFormField) Text() string {
 	return t.textField.Text
 }
 
+// RawText returns the text without the mask literals (see TextField.RawText).
+func (t *TextFormField) RawText() string {
+	return t.textField.RawText()
+}
+
+// MaskComplete returns true if all the required placeholders of the mask
+// have a value.
+func (t *TextFormField) MaskComplete() bool {
+	return t.textField.MaskComplete()
+}
+
+// SetMaskClass defines a custom mask placeholder (see TextField.SetMaskClass).
+// The initial text is formatted again with the new placeholder.
+func (t *TextFormField) SetMaskClass(placeholder rune, accept func(r rune) bool) {
+	t.textField.SetMaskClass(placeholder, accept)
+	t.filterInitialText()
+}
+
 // SetText manually sets the text of the TextFormField to the given text value.
 func (t *TextFormField) SetText(text string) {
 	// use this instead t.textField.Text to ensure we trigger the onChanged callback.
 	// TODO should this be fixed by Fyne??
+	t.textField.MaxLength = t.MaxLength
 	t.textField.SetText(text)
 	t.Refresh() // refresh the whole widget
 }
 
 // Reset resets the text value to the initial value.
 func (t *TextFormField) Reset() {
-	t.dirty = false
+	t.resetDirty()
+	t.forceValidation = true
 	t.SetText(t.initialText)
+	t.forceValidation = false
 	t.resetOverrideErr = true
 	t.textField.SetValidationError(nil)
 	t.resetOverrideErr = false
@@ -106,9 +149,20 @@ func (t *TextFormField) Save() {
 	}
 }
 
+func (t *TextFormField) draftValue() (string, bool) {
+	if t.isPasswordField {
+		return "", false
+	}
+	return t.textField.Text, true
+}
+
+func (t *TextFormField) restoreDraftValue(v string) {
+	t.SetText(v)
+}
+
 // ValidationError returns the underlying validation error.
 func (t *TextFormField) ValidationError() error {
-	if t.Validator != nil {
+	if t.hasValidator() {
 		// means that this was called before CreateRenderer and
 		// then Validator field is not copy to the textField yet,
 		// so Refresh to generate it
@@ -123,7 +177,7 @@ func (t *TextFormField) ValidationError() error {
 
 // Validate validates the field.
 func (t *TextFormField) Validate() error {
-	if t.Validator != nil {
+	if t.hasValidator() {
 		// means that this was called before CreateRenderer and
 		// then Validator field is not copy to the textField yet,
 		// so Refresh to generate it
@@ -131,29 +185,105 @@ func (t *TextFormField) Validate() error {
 			t.ExtendBaseFormField(t)
 			t.Refresh()
 		}
-		return t.textField.Validate()
+		return t.forceValidate(t.textField.Validate)
 	}
 	return nil
 }
 
+// fieldValidator returns the validator used by the internal widget.
+func (t *TextFormField) fieldValidator() fyne.StringValidator {
+	if !t.hasValidator() {
+		return nil
+	}
+	return t.runValidator
+}
+
+// runValidator runs the Validator only if the validation trigger allows it,
+// otherwise it keeps the current validation error.
+func (t *TextFormField) runValidator(text string) error {
+	if !t.hasValidator() {
+		return nil
+	}
+	if !t.shouldRunValidator() {
+		return t.validationError
+	}
+	if t.valueValidator != nil {
+		return t.valueValidator(text)
+	}
+	return t.Validator(text)
+}
+
+func (t *TextFormField) hasValidator() bool {
+	return t.Validator != nil || t.valueValidator != nil
+}
+
+func (t *TextFormField) counter() (string, color.Color, bool) {
+	if !t.ShowCounter {
+		return "", nil, false
+	}
+	n := len([]rune(t.textField.Text))
+	if t.MaxLength <= 0 {
+		return strconv.Itoa(n), theme.PlaceHolderColor(), true
+	}
+	text := strconv.Itoa(n) + "/" + strconv.Itoa(t.MaxLength)
+	switch {
+	case n > t.MaxLength:
+		return text, theme.ErrorColor(), true
+	case n*10 >= t.MaxLength*9:
+		// near the limit
+		return text, theme.PrimaryColor(), true
+	}
+	return text, theme.PlaceHolderColor(), true
+}
+
+// filterInitialText applies the mask or the input restriction of the text
+// field to the initial text.
+func (t *TextFormField) filterInitialText() {
+	t.initialText = t.textField.filterText(t.initialText)
+	t.textField.Text = t.initialText
+}
+
+func (t *TextFormField) leadingInset() float32 {
+	return t.adorned.leadingInset()
+}
+
+func (t *TextFormField) focusTarget() fyne.Focusable {
+	return t.textField
+}
+
 func (t *TextFormField) setupTextField() {
 	t.textField = NewTextField()
 	t.textField.Text = t.initialText
+	t.textField.hidePasswordRevealer = true
+	t.adorned = newAdornedTextField(t)
+	t.textField.onTypedShortcut = t.typedShortcut
+	t.textField.OnInputRejected = func(rejected string) {
+		if t.OnInputRejected != nil {
+			t.OnInputRejected(rejected)
+		}
+	}
 	t.textField.OnChanged = func(s string) {
 		if t.OnChanged != nil {
 			t.OnChanged(s)
 		}
 		t.didChange()
-		if s == "" {
+		if s == "" || t.ShowCounter || t.ShowClearButton {
 			t.Refresh()
 		}
 	}
+	t.textField.keyHandler = &t.BaseFormField
 	t.textField.onFocusChanged = func(focused bool) {
 		if focused && !t.dirty {
 			// handle special case to validate automatically a field
 			// after a Reset call
 			t.Validate()
 		}
+		if !focused {
+			t.blurred = true
+			if t.validateOnBlur() {
+				t.Validate()
+			}
+		}
 		t.Refresh()
 	}
 	t.textField.SetOnValidationChanged(func(e error) {
@@ -178,11 +308,8 @@ func (t *TextFormField) setupTextField() {
 func (t *TextFormField) CreateRenderer() fyne.WidgetRenderer {
 	t.ExtendBaseFormField(t)
 
-	if !t.isPasswordField {
-		t.textField.ActionItem = t.ActionItem
-	}
-	t.textField.Validator = t.Validator
-	t.textField.Validate() // validates as soon as it is created
+	t.textField.Validator = t.fieldValidator()
+	t.forceValidate(t.textField.Validate) // validates as soon as it is created
 
 	isFieldEmpty := func() bool {
 		return t.textField.Text == ""
@@ -195,10 +322,6 @@ func (t *TextFormField) CreateRenderer() fyne.WidgetRenderer {
 	updateInternalField := func() {
 		t.textField.TextStyle = t.TextStyle
 		t.textField.Wrapping = t.Wrapping
-		if !t.isPasswordField {
-			// REVIEW this won't work until it is fixed in Fyne.
-			t.textField.ActionItem = t.ActionItem
-		}
 		focusedAppearance := t.textField.focused && !t.Disabled()
 		// TODO change SetPlaceholder by r.widget.textField.PlaceHolder when it is fixed in fyne
 		if focusedAppearance && t.textField.Text == "" {
@@ -208,17 +331,18 @@ func (t *TextFormField) CreateRenderer() fyne.WidgetRenderer {
 		}
 		t.textField.Wrapping = t.Wrapping
 		t.textField.MaxLength = t.MaxLength
-		t.textField.Validator = t.Validator
+		t.textField.Validator = t.fieldValidator()
 		if t.Disabled() {
 			t.textField.Disable()
 		} else {
 			t.textField.Enable()
 		}
 		t.textField.Refresh()
+		t.adorned.Refresh()
 	}
 
 	return t.CreateBaseRenderer(
-		t.Label, t.Hint, t.textField,
+		t.Label, t.Hint, t.adorned,
 		isFieldEmpty, isFieldFocused,
 		updateInternalField,
 	)
@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/test"
 	"fyne.io/fyne/v2/theme"
 	"github.com/fpabl0/sparky-go/svalid"
@@ -349,3 +350,281 @@ func TestTextFormField_EmptyValidatorReset(t *testing.T) {
 	assert.NotNil(t, tf.validationError)
 	assert.Equal(t, emptyErr, tf.validationError)
 }
+
+func TestTextFormField_ValidationTrigger(t *testing.T) {
+	newField := func(trigger ValidationTrigger) (*TextFormField, fyne.Window, *formFieldRenderer) {
+		tf := NewTextFormField("Name", "")
+		tf.Validator = svalid.MinLength(3)
+		tf.Hint = "hint"
+		tf.ValidationTrigger = trigger
+		w := test.NewWindow(tf)
+		return tf, w, test.WidgetRenderer(tf).(*formFieldRenderer)
+	}
+	errText := svalid.MinLength(3)("").Error()
+
+	t.Run("on_change", func(t *testing.T) {
+		tf, w, r := newField(ValidationTriggerOnChange)
+		defer w.Close()
+		w.Canvas().Focus(tf.textField)
+		test.Type(tf.textField, "a")
+		assert.Equal(t, errText, r.hint.Text)
+		test.Type(tf.textField, "bc")
+		assert.Equal(t, "hint", r.hint.Text)
+	})
+
+	t.Run("on_blur", func(t *testing.T) {
+		tf, w, r := newField(ValidationTriggerOnBlur)
+		defer w.Close()
+		w.Canvas().Focus(tf.textField)
+		test.Type(tf.textField, "abc")
+		assert.Error(t, tf.ValidationError()) // not validated until blur
+		w.Canvas().Focus(nil)
+		assert.NoError(t, tf.ValidationError())
+		w.Canvas().Focus(tf.textField)
+		tf.textField.TypedKey(&fyne.KeyEvent{Name: fyne.KeyBackspace})
+		assert.NoError(t, tf.ValidationError())
+		w.Canvas().Focus(nil)
+		assert.Error(t, tf.ValidationError())
+		assert.Equal(t, errText, r.hint.Text)
+	})
+
+	t.Run("on_submit", func(t *testing.T) {
+		tf, w, r := newField(ValidationTriggerOnSubmit)
+		defer w.Close()
+		w.Canvas().Focus(tf.textField)
+		test.Type(tf.textField, "a")
+		w.Canvas().Focus(nil)
+		assert.Equal(t, "hint", r.hint.Text)
+		tf.markDirty()
+		assert.Equal(t, errText, r.hint.Text)
+	})
+
+	t.Run("after_blur", func(t *testing.T) {
+		tf, w, r := newField(ValidationTriggerAfterBlur)
+		defer w.Close()
+		w.Canvas().Focus(tf.textField)
+		test.Type(tf.textField, "abc")
+		tf.textField.TypedKey(&fyne.KeyEvent{Name: fyne.KeyBackspace})
+		assert.Equal(t, "hint", r.hint.Text)
+		w.Canvas().Focus(nil)
+		assert.Equal(t, errText, r.hint.Text)
+		w.Canvas().Focus(tf.textField)
+		test.Type(tf.textField, "c")
+		assert.Equal(t, "hint", r.hint.Text)
+		tf.textField.TypedKey(&fyne.KeyEvent{Name: fyne.KeyBackspace})
+		assert.Equal(t, errText, r.hint.Text)
+	})
+
+	t.Run("form_trigger", func(t *testing.T) {
+		tf := NewTextFormField("Name", "")
+		tf.Validator = svalid.MinLength(3)
+		f := NewForm(1, tf)
+		f.ValidationTrigger = ValidationTriggerOnSubmit
+		f.MarkDirtyOnSubmit = true
+		submitted := false
+		btn := f.CreateSubmitButton("Submit", func() { submitted = true })
+		w := test.NewWindow(container.NewVBox(f, btn))
+		defer w.Close()
+
+		tf.SetText("a")
+		r := test.WidgetRenderer(tf).(*formFieldRenderer)
+		assert.Equal(t, "", r.hint.Text) // the error is hidden until submit
+		test.Tap(btn)
+		assert.False(t, submitted)
+		assert.Equal(t, errText, r.hint.Text)
+		tf.SetText("abcd")
+		test.Tap(btn)
+		assert.True(t, submitted)
+	})
+
+	t.Run("form_trigger_without_mark_dirty", func(t *testing.T) {
+		tf := NewTextFormField("Name", "")
+		tf.Validator = svalid.MinLength(3)
+		f := NewForm(1, tf)
+		f.ValidationTrigger = ValidationTriggerOnSubmit
+		submitted := false
+		btn := f.CreateSubmitButton("Submit", func() { submitted = true })
+		w := test.NewWindow(container.NewVBox(f, btn))
+		defer w.Close()
+
+		assert.True(t, btn.Disabled())
+		// the change is not validated yet, so it does not block the submit.
+		tf.SetText("a")
+		assert.Error(t, tf.ValidationError())
+		assert.False(t, btn.Disabled())
+		test.Tap(btn)
+		assert.False(t, submitted)
+		r := test.WidgetRenderer(tf).(*formFieldRenderer)
+		assert.Equal(t, errText, r.hint.Text)
+		assert.True(t, btn.Disabled())
+
+		tf.SetText("abcd")
+		assert.False(t, btn.Disabled())
+		test.Tap(btn)
+		assert.True(t, submitted)
+	})
+}
+
+func TestTextFormField_MaxLength(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	tf := NewTextFormField("Code", "")
+	tf.MaxLength = 5
+	tf.ShowCounter = true
+	changes := []string{}
+	tf.OnChanged = func(s string) { changes = append(changes, s) }
+
+	w := test.NewWindow(tf)
+	defer w.Close()
+
+	tf.SetText("1234567")
+	assert.Equal(t, "12345", tf.Text())
+
+	r := test.WidgetRenderer(tf).(*formFieldRenderer)
+	assert.True(t, r.counter.Visible())
+	assert.Equal(t, "5/5", r.counter.Text)
+	assert.Equal(t, theme.PrimaryColor(), r.counter.Color)
+
+	tf.SetText("12")
+	assert.Equal(t, "2/5", r.counter.Text)
+	assert.Equal(t, theme.PlaceHolderColor(), r.counter.Color)
+
+	// pasted text is cut at the cursor to fit MaxLength
+	w.Canvas().Focus(tf.textField)
+	tf.textField.CursorColumn = 1
+	changes = nil
+	clipboard := test.NewClipboard()
+	clipboard.SetContent("abcdef")
+	tf.textField.TypedShortcut(&fyne.ShortcutPaste{Clipboard: clipboard})
+	assert.Equal(t, "1abc2", tf.Text())
+	assert.Equal(t, 4, tf.textField.CursorColumn)
+	assert.Equal(t, []string{"1abc2"}, changes)
+	assert.Equal(t, "5/5", r.counter.Text)
+
+	tf.ShowCounter = false
+	tf.Refresh()
+	assert.False(t, r.counter.Visible())
+}
+
+func TestTextFormField_Adornments(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	tf := NewTextFormField("Price", "")
+	tf.Leading = NewTextAdornment("$")
+	tf.Trailing = NewIconAdornment(theme.InfoIcon())
+	tf.ShowClearButton = true
+
+	w := test.NewWindow(tf)
+	defer w.Close()
+	w.Resize(fyne.NewSize(300, 100))
+
+	r := test.WidgetRenderer(tf.adorned).(*adornedTextFieldRenderer)
+	assert.Equal(t, []fyne.CanvasObject{tf.Leading}, r.leading)
+	assert.Equal(t, []fyne.CanvasObject{tf.Trailing}, r.trailing)
+	assert.Greater(t, tf.textField.Position().X, tf.Leading.Position().X)
+	assert.Greater(t, tf.Trailing.Position().X, tf.textField.Position().X)
+
+	// the label is placed after the leading content while it is not stacked.
+	fr := test.WidgetRenderer(tf).(*formFieldRenderer)
+	assert.Equal(t, fr.fieldInsetPad()+tf.leadingInset(), fr.nonStackedLabelPosX())
+
+	tf.SetText("12")
+	assert.Contains(t, r.trailing, fyne.CanvasObject(tf.adorned.clearButton))
+	test.Tap(tf.adorned.clearButton)
+	assert.Equal(t, "", tf.Text())
+	assert.NotContains(t, r.trailing, fyne.CanvasObject(tf.adorned.clearButton))
+	assert.Equal(t, tf.textField, w.Canvas().Focused())
+}
+
+func TestTextFormField_AdornmentErrorLine(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	tf := NewTextFormField("Name", "")
+	tf.Validator = svalid.MinLength(3)
+	tf.ValidationTrigger = ValidationTriggerOnSubmit
+	tf.ShowClearButton = true
+
+	w := test.NewWindow(tf)
+	defer w.Close()
+
+	r := test.WidgetRenderer(tf.adorned).(*adornedTextFieldRenderer)
+	w.Canvas().Focus(tf.textField)
+	test.Type(tf.textField, "a")
+	w.Canvas().Focus(nil)
+	// the line follows the hint, which does not show the error until submit.
+	assert.Equal(t, theme.ShadowColor(), r.line.FillColor)
+	tf.markDirty()
+	assert.Equal(t, theme.ErrorColor(), r.line.FillColor)
+}
+
+func TestTextFormField_PasswordReveal(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	tf := NewPasswordTextFormField("Password", "secret")
+	tf.ActionItem = NewIconAdornment(theme.InfoIcon())
+
+	w := test.NewWindow(tf)
+	defer w.Close()
+
+	// the entry does not add its own revealer.
+	assert.Nil(t, tf.textField.ActionItem)
+	r := test.WidgetRenderer(tf.adorned).(*adornedTextFieldRenderer)
+	assert.Equal(t, []fyne.CanvasObject{tf.ActionItem, tf.adorned.revealButton}, r.trailing)
+
+	assert.True(t, tf.textField.Password)
+	test.Tap(tf.adorned.revealButton)
+	assert.False(t, tf.textField.Password)
+	assert.Equal(t, theme.VisibilityIcon(), tf.adorned.revealButton.icon.Resource)
+	test.Tap(tf.adorned.revealButton)
+	assert.True(t, tf.textField.Password)
+}
+
+func TestTextFormField_FilteredInput(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	tf := NewRestrictTextFormField("Age", "1a2", RestrictInputInteger)
+	assert.Equal(t, "12", tf.Text())
+
+	rejected := ""
+	tf.OnInputRejected = func(s string) { rejected += s }
+
+	w := test.NewWindow(tf)
+	defer w.Close()
+
+	tf.SetText("3x4y")
+	assert.Equal(t, "34", tf.Text())
+	assert.Equal(t, "xy", rejected)
+
+	rejected = ""
+	w.Canvas().Focus(tf.textField)
+	tf.textField.CursorColumn = 1
+	clipboard := test.NewClipboard()
+	clipboard.SetContent("5b6")
+	tf.textField.TypedShortcut(&fyne.ShortcutPaste{Clipboard: clipboard})
+	assert.Equal(t, "3564", tf.Text())
+	assert.Equal(t, 3, tf.textField.CursorColumn)
+	assert.Equal(t, "b", rejected)
+
+	rejected = ""
+	test.Type(tf.textField, "z")
+	assert.Equal(t, "3564", tf.Text())
+	assert.Equal(t, "z", rejected)
+}
+
+func TestTextFormField_MaskedInput(t *testing.T) {
+	test.ApplyTheme(t, theme.LightTheme())
+	tf := NewMaskedTextFormField("Phone", "5551234567", "+(999) 999-9999", "")
+	assert.Equal(t, "+(555) 123-4567", tf.Text())
+
+	rejected := ""
+	tf.OnInputRejected = func(s string) { rejected += s }
+	tf.SetText("+(555) 12ab")
+	assert.Equal(t, "+(555) 12", tf.Text())
+	assert.Equal(t, "ab", rejected)
+
+	w := test.NewWindow(tf)
+	defer w.Close()
+
+	tf.SetText("")
+	w.Canvas().Focus(tf.textField)
+	clipboard := test.NewClipboard()
+	clipboard.SetContent("999-88a")
+	tf.textField.TypedShortcut(&fyne.ShortcutPaste{Clipboard: clipboard})
+	assert.Equal(t, "+(999) 88", tf.Text())
+	assert.Equal(t, len("+(999) 88"), tf.textField.CursorColumn)
+}
@@ -0,0 +1,259 @@
+package swid
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// WizardStep defines a step of a Wizard.
+type WizardStep struct {
+	Title string
+	Form  *Form
+	// Skip is evaluated every time the wizard moves between steps.
+	// If it returns true, the step is skipped.
+	Skip func() bool
+
+	label        *widget.Label
+	nextButton   *widget.Button
+	finishButton *widget.Button
+}
+
+// NewWizardStep creates a new wizard step.
+func NewWizardStep(title string, form *Form) *WizardStep {
+	return &WizardStep{Title: title, Form: form}
+}
+
+func (s *WizardStep) skipped() bool {
+	return s.Skip != nil && s.Skip()
+}
+
+// Wizard defines a multi-step form widget. Each step is a Form, and the
+// user can only move to the next step when the current one is valid.
+type Wizard struct {
+	widget.BaseWidget
+	BackText   string
+	NextText   string
+	FinishText string
+
+	OnStepChanged func(step int)
+	OnFinished    func()
+
+	steps      []*WizardStep
+	current    int
+	history    []int
+	backButton *widget.Button
+}
+
+// NewWizard creates a new wizard widget.
+func NewWizard(steps ...*WizardStep) *Wizard {
+	w := &Wizard{
+		BackText:   "Back",
+		NextText:   "Next",
+		FinishText: "Finish",
+		steps:      steps,
+	}
+	w.ExtendBaseWidget(w)
+	w.backButton = widget.NewButtonWithIcon(w.BackText, theme.NavigateBackIcon(), w.Back)
+	for _, step := range steps {
+		step.label = widget.NewLabel(step.Title)
+		// reuse the form submit buttons, so they are enabled only when
+		// the step form is valid.
+		step.nextButton = step.Form.CreateSubmitButton(w.NextText, w.Next)
+		step.finishButton = step.Form.CreateSubmitButton(w.FinishText, w.Finish)
+	}
+	w.current = w.stepAfter(-1)
+	if w.current < 0 {
+		w.current = 0
+	}
+	return w
+}
+
+// ===============================================================
+// Methods
+// ===============================================================
+
+// CurrentStep returns the index of the current step.
+func (w *Wizard) CurrentStep() int {
+	return w.current
+}
+
+// IsLastStep returns true if there are no more steps to show after
+// the current one.
+func (w *Wizard) IsLastStep() bool {
+	return w.stepAfter(w.current) < 0
+}
+
+// Next moves to the next step that is not skipped. It does nothing if
+// the current step is invalid or it is the last one.
+func (w *Wizard) Next() {
+	if len(w.steps) == 0 || !w.steps[w.current].Form.IsValid() {
+		return
+	}
+	next := w.stepAfter(w.current)
+	if next < 0 {
+		return
+	}
+	w.history = append(w.history, w.current)
+	w.setCurrent(next)
+}
+
+// Back moves to the previous visited step.
+func (w *Wizard) Back() {
+	if len(w.history) == 0 {
+		return
+	}
+	prev := w.history[len(w.history)-1]
+	w.history = w.history[:len(w.history)-1]
+	w.setCurrent(prev)
+}
+
+// Finish saves all the steps and triggers the OnFinished callback.
+// It does nothing if the current step is invalid.
+func (w *Wizard) Finish() {
+	if len(w.steps) == 0 || !w.steps[w.current].Form.IsValid() {
+		return
+	}
+	w.Save()
+	if w.OnFinished != nil {
+		w.OnFinished()
+	}
+}
+
+// Save saves the form of all the steps that are not skipped.
+func (w *Wizard) Save() {
+	for _, step := range w.steps {
+		if step.skipped() {
+			continue
+		}
+		step.Form.Save()
+	}
+}
+
+// Reset resets the form of all the steps and moves to the first step.
+func (w *Wizard) Reset() {
+	for _, step := range w.steps {
+		step.Form.Reset()
+	}
+	w.history = nil
+	first := w.stepAfter(-1)
+	if first < 0 {
+		first = 0
+	}
+	w.setCurrent(first)
+}
+
+func (w *Wizard) setCurrent(step int) {
+	if step == w.current {
+		w.Refresh()
+		return
+	}
+	w.current = step
+	w.Refresh()
+	if w.OnStepChanged != nil {
+		w.OnStepChanged(step)
+	}
+}
+
+// stepAfter returns the index of the next step that is not skipped, or -1
+// if there is none.
+func (w *Wizard) stepAfter(index int) int {
+	for i := index + 1; i < len(w.steps); i++ {
+		if !w.steps[i].skipped() {
+			return i
+		}
+	}
+	return -1
+}
+
+// ===============================================================
+// Renderer
+// ===============================================================
+
+// CreateRenderer implements fyne.Widget.
+func (w *Wizard) CreateRenderer() fyne.WidgetRenderer {
+	w.ExtendBaseWidget(w)
+	indicator := container.NewHBox()
+	forms := container.NewMax()
+	buttons := container.NewHBox(layout.NewSpacer(), w.backButton)
+	for _, step := range w.steps {
+		indicator.Add(step.label)
+		forms.Add(step.Form)
+		buttons.Add(step.nextButton)
+		buttons.Add(step.finishButton)
+	}
+	r := &wizardRenderer{
+		widget: w,
+		content: container.NewBorder(
+			container.NewVBox(indicator, widget.NewSeparator()),
+			buttons, nil, nil,
+			forms,
+		),
+	}
+	r.objects = []fyne.CanvasObject{r.content}
+	r.Refresh()
+	return r
+}
+
+type wizardRenderer struct {
+	widget  *Wizard
+	content *fyne.Container
+	objects []fyne.CanvasObject
+}
+
+func (r *wizardRenderer) Destroy() {}
+
+func (r *wizardRenderer) Layout(size fyne.Size) {
+	r.content.Resize(size)
+}
+
+func (r *wizardRenderer) MinSize() fyne.Size {
+	return r.content.MinSize()
+}
+
+func (r *wizardRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *wizardRenderer) Refresh() {
+	w := r.widget
+	isLast := w.IsLastStep()
+	n := 0
+	for i, step := range w.steps {
+		current := i == w.current
+		if step.skipped() && !current {
+			step.label.Hide()
+		} else {
+			n++
+			step.label.Show()
+		}
+		step.label.SetText(fmt.Sprintf("%d. %s", n, step.Title))
+		step.label.TextStyle.Bold = current
+		step.label.Refresh()
+
+		step.nextButton.SetText(w.NextText)
+		step.finishButton.SetText(w.FinishText)
+		if current {
+			step.Form.Show()
+		} else {
+			step.Form.Hide()
+		}
+		setVisible(step.nextButton, current && !isLast)
+		setVisible(step.finishButton, current && isLast)
+	}
+	w.backButton.SetText(w.BackText)
+	setVisible(w.backButton, len(w.history) > 0)
+	r.content.Refresh()
+}
+
+func setVisible(o fyne.CanvasObject, visible bool) {
+	if visible {
+		o.Show()
+	} else {
+		o.Hide()
+	}
+}
@@ -0,0 +1,111 @@
+package swid
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/test"
+	"github.com/fpabl0/sparky-go/svalid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWizard(t *testing.T) {
+	var data struct {
+		Name    string
+		Company string
+		Email   string
+	}
+
+	name := NewTextFormField("Name", "")
+	name.Validator = svalid.NotEmpty()
+	name.OnSaved = func(s string) { data.Name = s }
+
+	business := NewSelectFormField("Account Type", "Personal", []string{"Personal", "Business"})
+
+	company := NewTextFormField("Company", "")
+	company.Validator = svalid.NotEmpty()
+	company.OnSaved = func(s string) { data.Company = s }
+
+	email := NewTextFormField("Email", "")
+	email.Validator = svalid.Email()
+	email.OnSaved = func(s string) { data.Email = s }
+
+	companyStep := NewWizardStep("Company", NewForm(1, company))
+	companyStep.Skip = func() bool { return business.Selected() != "Business" }
+
+	wiz := NewWizard(
+		NewWizardStep("Personal", NewForm(1, name, business)),
+		companyStep,
+		NewWizardStep("Contact", NewForm(1, email)),
+	)
+	finished := false
+	wiz.OnFinished = func() { finished = true }
+
+	w := test.NewWindow(wiz)
+	defer w.Close()
+
+	assert.Equal(t, 0, wiz.CurrentStep())
+	assert.False(t, wiz.backButton.Visible())
+	assert.True(t, wiz.steps[0].nextButton.Disabled())
+
+	wiz.Next()
+	assert.Equal(t, 0, wiz.CurrentStep())
+
+	name.SetText("Peter")
+	assert.False(t, wiz.steps[0].nextButton.Disabled())
+	test.Tap(wiz.steps[0].nextButton)
+	// company step is skipped
+	assert.Equal(t, 2, wiz.CurrentStep())
+	assert.True(t, wiz.IsLastStep())
+	assert.True(t, wiz.steps[2].finishButton.Visible())
+	assert.False(t, wiz.steps[2].nextButton.Visible())
+	assert.True(t, wiz.backButton.Visible())
+
+	test.Tap(wiz.backButton)
+	assert.Equal(t, 0, wiz.CurrentStep())
+
+	business.selectField.SetSelected("Business")
+	wiz.Next()
+	assert.Equal(t, 1, wiz.CurrentStep())
+	assert.True(t, wiz.steps[1].Form.Visible())
+	assert.False(t, wiz.steps[0].Form.Visible())
+	company.SetText("ACME")
+	wiz.Next()
+	assert.Equal(t, 2, wiz.CurrentStep())
+
+	wiz.Finish()
+	assert.False(t, finished)
+	email.SetText("peter@example.com")
+	test.Tap(wiz.steps[2].finishButton)
+	assert.True(t, finished)
+	assert.Equal(t, "Peter", data.Name)
+	assert.Equal(t, "ACME", data.Company)
+	assert.Equal(t, "peter@example.com", data.Email)
+
+	wiz.Reset()
+	assert.Equal(t, 0, wiz.CurrentStep())
+	assert.Equal(t, "", name.Text())
+}
+
+func TestWizard_EnterOnLastStep(t *testing.T) {
+	name := NewTextFormField("Name", "")
+	email := NewTextFormField("Email", "")
+	wiz := NewWizard(
+		NewWizardStep("Personal", NewForm(1, name)),
+		NewWizardStep("Contact", NewForm(1, email)),
+	)
+	finished := false
+	wiz.OnFinished = func() { finished = true }
+
+	w := test.NewWindow(wiz)
+	defer w.Close()
+
+	w.Canvas().Focus(name.textField)
+	name.textField.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	assert.Equal(t, 1, wiz.CurrentStep())
+	assert.False(t, finished)
+
+	w.Canvas().Focus(email.textField)
+	email.textField.TypedKey(&fyne.KeyEvent{Name: fyne.KeyReturn})
+	assert.True(t, finished)
+}